@@ -0,0 +1,80 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import "fmt"
+
+const (
+	AssertLog   AssertAction = iota //Only log failed assertions, the program continues.
+	AssertPanic                     //Call panic() with the message after logging a failed assertion.
+	AssertExit                      //Run the exit hooks and exit with the Logger's exit code after logging a failed assertion, like Die.
+)
+
+// Represents what a Logger does after logging a failed assertion, see (l *Logger) Assert.
+type AssertAction int
+
+// assertAssertAction panics if action is not a defined AssertAction.
+func assertAssertAction(action AssertAction) {
+	if err := checkAssertAction(action); err != nil {
+		panic("Programming error: (l *Logger) SetAssertAction(): " + err.Error())
+	}
+}
+
+// checkAssertAction returns an error if action is not a defined AssertAction.
+func checkAssertAction(action AssertAction) error {
+	if action < AssertLog || action > AssertExit {
+		return fmt.Errorf("Undefined assert action %d", action)
+	}
+	return nil
+}
+
+// String returns the name of the AssertAction.
+func (r AssertAction) String() string {
+	switch r {
+	case AssertLog:
+		return "Log"
+	case AssertPanic:
+		return "Panic"
+	case AssertExit:
+		return "Exit"
+	}
+	return "Undefined"
+}
+
+// AssertAction returns what the Logger does after logging a failed assertion.
+func (l *Logger) AssertAction() AssertAction {
+	return AssertAction(l.assertAction.Load())
+}
+
+// SetAssertAction sets what the Logger does after logging a failed assertion, the default is AssertLog.
+// Setting an undefined AssertAction will cause a panic.
+func (l *Logger) SetAssertAction(action AssertAction) {
+	assertAssertAction(action)
+	l.assertAction.Store(int32(action))
+}
+
+// Assert checks an invariant for defensive programming. If cond is false, the message "Assertion failed: " followed
+// by v is sent with loglevel LevelPanic to the Logger, then the Logger's AssertAction is carried out. Assert returns
+// cond, so the caller can bail out if the program continues:
+//
+//	if !l.Assert(conn != nil, "no connection for ", id) {
+//		return
+//	}
+func (l *Logger) Assert(cond bool, v ...any) bool {
+	if cond {
+		return true
+	}
+	msg := "Assertion failed"
+	if len(v) > 0 {
+		msg += ": " + fmt.Sprint(v...)
+	}
+	l.Output(Record{Level: LevelPanic, Message: msg})
+	switch l.AssertAction() {
+	case AssertPanic:
+		l.Flush()
+		panic(msg)
+	case AssertExit:
+		l.exit(l.ExitCode())
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAssert(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	if !l.Assert(true, "not logged") || l.Assert(false, "count is ", 3) || l.Assert(false) {
+		t.Error("Assert did not return the condition")
+	}
+	expected := "[Panic] - Assertion failed: count is 3\n[Panic] - Assertion failed\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	code := -1
+	l.SetExitFunc(func(c int) { code = c })
+	l.SetAssertAction(AssertExit)
+	l.Assert(false)
+	if code != 1 {
+		t.Errorf("Expected exit code 1. Got %d", code)
+	}
+	l.SetAssertAction(AssertPanic)
+	defer func() {
+		if v := recover(); v != "Assertion failed: panicked" {
+			t.Errorf("Expected a panic with %q. Got %v", "Assertion failed: panicked", v)
+		}
+	}()
+	l.Assert(false, "panicked")
+}
+
+func TestSetAssertActionInvalid(t *testing.T) {
+	const expected = "Programming error: (l *Logger) SetAssertAction(): Undefined assert action 7"
+	defer func() {
+		if v := recover(); v != expected {
+			t.Errorf("Expected a panic with %q. Got %v", expected, v)
+		}
+	}()
+	New(io.Discard, LevelInfo, loglevelDelimiter).SetAssertAction(7)
+}
@@ -0,0 +1,115 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrClosed is returned when a record is sent to a Logger that has been closed.
+var ErrClosed = errors.New("Logger is closed")
+
+// asyncItem is an element of an asyncQueue. It either holds a record to write or a channel that
+// is closed once all previously queued records have been written.
+type asyncItem struct {
+	rec     *Record
+	flushed chan struct{}
+}
+
+// asyncQueue holds the records of an asynchronous Logger until its background goroutine writes them.
+type asyncQueue struct {
+	mu     sync.RWMutex // Protects closed and guards items against being closed while sending.
+	closed bool
+	items  chan asyncItem
+	done   chan struct{}
+}
+
+// NewAsync constructs a new asynchronous Logger. It behaves like a Logger constructed by New, but instead of writing
+// a record immediately, it queues the record and lets a background goroutine write it. The queue holds up to queueSize
+// records, if it is full, sending a record blocks until there is space again. As records are written after the print
+// method has returned, write errors are not returned to the caller, use (l *Logger) SetErrorHandler to receive them. Call Flush to wait until all queued records have been
+// written and Close to stop the background goroutine.
+func NewAsync(w io.Writer, level Level, delimiter string, queueSize int) *Logger {
+	if queueSize < 0 {
+		panic("Programming error: logger.NewAsync: Passed negative queue size")
+	}
+	l := New(w, level, delimiter)
+	l.startAsync(queueSize)
+	return l
+}
+
+// startAsync makes the Logger asynchronous with a queue of queueSize records and starts its background goroutine.
+func (l *Logger) startAsync(queueSize int) {
+	l.async = &asyncQueue{
+		items: make(chan asyncItem, queueSize),
+		done:  make(chan struct{}),
+	}
+	go l.asyncWorker()
+}
+
+// closeAsync writes all queued records and stops the background goroutine of an asynchronous Logger.
+// Records sent to the Logger afterwards are discarded.
+func (l *Logger) closeAsync() {
+	l.async.mu.Lock()
+	if !l.async.closed {
+		l.async.closed = true
+		close(l.async.items)
+	}
+	l.async.mu.Unlock()
+	<-l.async.done
+}
+
+// Flush blocks until all records queued or buffered before the call have been written, then flushes the Logger's writers
+// that buffer data, like a BufferedWriter or a bufio.Writer. The first error of a writer is returned. Called from within
+// the Logger while it writes records, e.g. by a Hook, Flush returns ErrReentrancy instead of waiting for itself.
+func (l *Logger) Flush() error {
+	if l.reentrant() {
+		return ErrReentrancy
+	}
+	if l.async != nil {
+		flushed := make(chan struct{})
+		if err := l.enqueue(asyncItem{flushed: flushed}); err != nil {
+			return err
+		}
+		<-flushed
+	}
+	if l.shards != nil {
+		if err := l.flushShards(); err != nil {
+			return err
+		}
+	}
+	l.lock()
+	defer l.unlock()
+	return l.flushWriters()
+}
+
+// enqueue passes item to the background goroutine of an asynchronous Logger. The caller must not hold the Logger's lock.
+func (l *Logger) enqueue(item asyncItem) error {
+	l.async.mu.RLock()
+	defer l.async.mu.RUnlock()
+	if l.async.closed {
+		if item.rec != nil {
+			l.dropped.Add(1)
+		}
+		return ErrClosed
+	}
+	l.async.items <- item
+	return nil
+}
+
+// asyncWorker writes the queued records of an asynchronous Logger until its queue is closed.
+func (l *Logger) asyncWorker() {
+	for item := range l.async.items {
+		if item.rec != nil {
+			l.lock()
+			l.write(item.rec)
+			l.unlock()
+		}
+		if item.flushed != nil {
+			close(item.flushed)
+		}
+	}
+	close(l.async.done)
+}
@@ -0,0 +1,63 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestAsync(t *testing.T) {
+	const goroutines = 8
+	const records = 1000
+	b := new(bytes.Buffer)
+	l := NewAsync(b, LevelDebug, loglevelDelimiter, 64)
+	wg := new(sync.WaitGroup)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(id int) {
+			for j := 0; j < records; j++ {
+				l.Debugf("Goroutine %02d: Message %04d", id, j)
+			}
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	lines := 0
+	scanner := bufio.NewScanner(bytes.NewReader(b.Bytes()))
+	for scanner.Scan() {
+		lines++
+	}
+	if lines != goroutines*records {
+		t.Errorf("Expected %d records, got %d", goroutines*records, lines)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Info("after close"); err != ErrClosed || l.Dropped() != 1 {
+		t.Errorf("Expected %v, got %v", ErrClosed, err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Closing twice returned error: %s", err)
+	}
+}
+
+func TestAsyncOrder(t *testing.T) {
+	b := new(bytes.Buffer)
+	l := NewAsync(b, LevelDebug, loglevelDelimiter, 4)
+	expect := new(bytes.Buffer)
+	for i := 0; i < 100; i++ {
+		l.Infof("%d", i)
+		fmt.Fprintf(expect, "[Info]%s%d\n", loglevelDelimiter, i)
+	}
+	l.Close()
+	if b.String() != expect.String() {
+		t.Errorf("Records were not written in order: %q", b.String())
+	}
+}
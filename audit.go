@@ -0,0 +1,172 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// auditMACKey separates the MAC from the rest of an audit record.
+const auditMACKey = `,"mac":"`
+
+// auditRecord is the layout of a record written by an AuditLog, without its MAC.
+type auditRecord struct {
+	Seq     uint64     `json:"seq"`
+	Time    string     `json:"time"`
+	Prefix  string     `json:"prefix,omitempty"`
+	Message string     `json:"message"`
+	Fields  jsonFields `json:"fields,omitempty"`
+}
+
+// AuditLog is a Sink that writes tamper-evident audit records. Every record is written as a JSON object on a single
+// line and carries a sequence number starting at 1. If the AuditLog has a key, every record additionally carries
+// an HMAC-SHA256 over the record and the MAC of its predecessor. Modifying, inserting, deleting or reordering
+// records breaks the chain, which VerifyAuditLog detects. Add an AuditLog to a Logger for LevelAudit only:
+//
+//	l.AddSink(audit, logger.LevelAudit)
+//
+// An AuditLog can be used by multiple goroutines.
+type AuditLog struct {
+	mu  sync.Mutex
+	w   io.Writer
+	key []byte
+	seq uint64
+	mac []byte
+}
+
+// NewAuditLog returns an AuditLog that starts a new chain of records on w. If key is nil, the records are not MACed.
+func NewAuditLog(w io.Writer, key []byte) *AuditLog {
+	if w == nil {
+		panic("Programming error: logger.NewAuditLog: Passed nil as writer")
+	}
+	return &AuditLog{w: w, key: key}
+}
+
+// OpenAuditLog opens the audit file at path for appending, creating it if it does not exist. The existing records
+// are verified with key and the chain is continued after the last one. If the verification fails, the error
+// is returned and the file is not opened. The file is closed by Close.
+func OpenAuditLog(path string, key []byte) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	seq, mac, err := verifyAuditLog(f, key)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &AuditLog{w: f, key: key, seq: seq, mac: mac}, nil
+}
+
+// VerifyAuditLog reads the records written by an AuditLog from r and checks their sequence numbers and, if key
+// is not nil, their MACs. It returns an error describing the first record that breaks the chain.
+func VerifyAuditLog(r io.Reader, key []byte) error {
+	_, _, err := verifyAuditLog(r, key)
+	return err
+}
+
+// Close closes the underlying writer if it implements io.Closer.
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if c, ok := a.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// WriteRecord writes rec as the next record of the chain. It implements Sink.
+func (a *AuditLog) WriteRecord(rec *Record) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	body, err := json.Marshal(&auditRecord{
+		Seq:     a.seq + 1,
+		Time:    rec.Time.UTC().Format(time.RFC3339Nano),
+		Prefix:  rec.Prefix,
+		Message: rec.Message,
+		Fields:  rec.Fields,
+	})
+	if err != nil {
+		return err
+	}
+	line := body
+	var mac []byte
+	if a.key != nil {
+		mac = auditMAC(a.key, a.mac, body)
+		line = append(body[:len(body)-1:len(body)-1], auditMACKey...)
+		line = append(line, hex.EncodeToString(mac)...)
+		line = append(line, `"}`...)
+	}
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	a.seq++
+	a.mac = mac
+	return nil
+}
+
+// Audit sends a record of loglevel LevelAudit with the key/value pairs kv attached to the Logger. Audit records
+// pass every Logger regardless of its loglevel, they are neither sampled nor deduplicated.
+func (l *Logger) Audit(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelAudit, msg, kv...)
+}
+
+// auditMAC returns the HMAC-SHA256 of the record body chained to the MAC prev of the preceding record.
+func auditMAC(key, prev, body []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(prev)
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+// verifyAuditLog verifies the records read from r, see VerifyAuditLog. It returns the sequence number and the MAC
+// of the last record.
+func verifyAuditLog(r io.Reader, key []byte) (seq uint64, mac []byte, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		body := line
+		var recordMAC []byte
+		if key != nil {
+			i := bytes.LastIndex(line, []byte(auditMACKey))
+			if i < 0 || !bytes.HasSuffix(line, []byte(`"}`)) {
+				return seq, mac, fmt.Errorf("Audit record %d has no MAC", seq+1)
+			}
+			if recordMAC, err = hex.DecodeString(string(line[i+len(auditMACKey) : len(line)-2])); err != nil {
+				return seq, mac, fmt.Errorf("Audit record %d has a malformed MAC", seq+1)
+			}
+			body = append(line[:i:i], '}')
+		}
+		var rec struct {
+			Seq uint64 `json:"seq"`
+		}
+		if err := json.Unmarshal(body, &rec); err != nil {
+			return seq, mac, fmt.Errorf("Audit record %d is malformed: %w", seq+1, err)
+		}
+		if rec.Seq != seq+1 {
+			return seq, mac, fmt.Errorf("Audit record %d has sequence number %d", seq+1, rec.Seq)
+		}
+		if key != nil {
+			if !hmac.Equal(recordMAC, auditMAC(key, mac, body)) {
+				return seq, mac, fmt.Errorf("Audit record %d has an invalid MAC", seq+1)
+			}
+			mac = recordMAC
+		}
+		seq++
+	}
+	if err := scanner.Err(); err != nil {
+		return seq, mac, err
+	}
+	return seq, mac, nil
+}
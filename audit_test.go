@@ -0,0 +1,75 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	key := []byte("secret")
+	text := new(strings.Builder)
+	trail := new(bytes.Buffer)
+	l := New(text, LevelPanic, loglevelDelimiter)
+	l.SetSampler(NewEveryNSampler(2))
+	l.AddSink(NewAuditLog(trail, key), LevelAudit)
+	l.Error("filtered")
+	l.Audit("login", "user", "bob")
+	l.Audit("login", "user", "bob")
+	l.Audit("logout", "user", "bob")
+	expected := "[Audit] - login - user=bob\n[Audit] - login - user=bob\n[Audit] - logout - user=bob\n"
+	if text.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, text.String())
+	}
+	lines := strings.SplitAfter(trail.String(), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], `{"seq":1,"time":`) || !strings.Contains(lines[2], `"message":"logout"`) {
+		t.Fatalf("Unexpected audit trail %q", trail.String())
+	}
+	if err := VerifyAuditLog(strings.NewReader(trail.String()), key); err != nil {
+		t.Errorf("Expected the audit trail to verify. Got %s", err)
+	}
+	tampered := []string{
+		lines[0] + lines[2],
+		lines[1] + lines[0] + lines[2],
+		strings.Replace(trail.String(), "logout", "delete", 1),
+	}
+	for _, trail := range tampered {
+		if err := VerifyAuditLog(strings.NewReader(trail), key); err == nil {
+			t.Errorf("Expected the tampered audit trail %q to fail verification", trail)
+		}
+	}
+	if err := VerifyAuditLog(strings.NewReader(trail.String()), []byte("wrong")); err == nil {
+		t.Error("Expected verification with the wrong key to fail")
+	}
+}
+
+func TestOpenAuditLog(t *testing.T) {
+	key := []byte("secret")
+	path := filepath.Join(t.TempDir(), "audit.log")
+	for i := 0; i < 2; i++ {
+		a, err := OpenAuditLog(path, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := New(new(strings.Builder), LevelInfo, loglevelDelimiter)
+		l.AddSink(a, LevelAudit)
+		l.Audit("started")
+		if err := a.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyAuditLog(bytes.NewReader(b), key); err != nil || !strings.Contains(string(b), `"seq":2`) {
+		t.Errorf("Expected a continued chain of 2 records. Got %q and error %v", b, err)
+	}
+	if _, err := OpenAuditLog(path, []byte("wrong")); err == nil {
+		t.Error("Expected opening with the wrong key to fail")
+	}
+}
@@ -0,0 +1,335 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Binary record encoding constants.
+const (
+	binaryVersion       = 1        // Version byte that starts every encoded record.
+	maxBinaryRecordSize = 64 << 20 // Largest encoded record a BinaryDecoder accepts.
+)
+
+// Type tags of the field values in the binary record encoding.
+const (
+	binaryNil byte = iota
+	binaryString
+	binaryInt
+	binaryUint
+	binaryFloat
+	binaryBool
+	binaryTime
+	binaryDuration
+)
+
+// errBinaryRecord is returned if an encoded record is malformed.
+var errBinaryRecord = errors.New("Malformed binary record")
+
+// MarshalBinary implements encoding.BinaryMarshaler. It encodes the record in a compact binary form that
+// UnmarshalBinary decodes. Field values of type string, bool, time.Time, time.Duration and of the integer
+// and floating point types keep their type, other values are stored as their text representation.
+// The program counter of the caller is not encoded.
+func (r *Record) MarshalBinary() ([]byte, error) {
+	return appendBinaryRecord(nil, r), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It decodes a record encoded by MarshalBinary.
+// Times are decoded in the local time zone.
+func (r *Record) UnmarshalBinary(data []byte) error {
+	d := binaryReader{b: data}
+	if d.byte() != binaryVersion {
+		return errors.New("Unsupported binary record version")
+	}
+	var rec Record
+	rec.Level = Level(d.varint())
+	rec.Time = d.time()
+	rec.Prefix = d.string()
+	rec.Message = d.string()
+	rec.Stack = d.string()
+	rec.Caller.Function = d.string()
+	rec.Caller.File = d.string()
+	rec.Caller.Line = int(d.uvarint())
+	count := d.uvarint()
+	if count > uint64(len(d.b)) {
+		return errBinaryRecord
+	}
+	for i := uint64(0); i < count && d.err == nil; i++ {
+		rec.Fields = append(rec.Fields, Field{Key: d.string(), Value: d.value()})
+	}
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.b) > 0 {
+		return errBinaryRecord
+	}
+	*r = rec
+	return nil
+}
+
+// BinaryEncoder is a Sink that writes records in the binary record encoding, see Record.MarshalBinary.
+// Every record is preceded by its length as unsigned varint, BinaryDecoder reads such a stream.
+// A BinaryEncoder can be used by multiple goroutines.
+type BinaryEncoder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf []byte
+}
+
+// NewBinaryEncoder returns a BinaryEncoder that writes to w.
+func NewBinaryEncoder(w io.Writer) *BinaryEncoder {
+	if w == nil {
+		panic("Programming error: logger.NewBinaryEncoder: Passed nil as writer")
+	}
+	return &BinaryEncoder{w: w}
+}
+
+// Close closes the underlying writer if it implements io.Closer.
+func (e *BinaryEncoder) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if c, ok := e.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// WriteRecord writes rec to the underlying writer with a single call. It implements Sink.
+func (e *BinaryEncoder) WriteRecord(rec *Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	body := appendBinaryRecord(e.buf[:0], rec)
+	frame := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(body)), uint64(len(body)))
+	frame = append(frame, body...)
+	e.buf = body[:0]
+	_, err := e.w.Write(frame)
+	return err
+}
+
+// BinaryDecoder reads records written by a BinaryEncoder.
+type BinaryDecoder struct {
+	r *bufio.Reader
+}
+
+// NewBinaryDecoder returns a BinaryDecoder that reads from r.
+func NewBinaryDecoder(r io.Reader) *BinaryDecoder {
+	if r == nil {
+		panic("Programming error: logger.NewBinaryDecoder: Passed nil as reader")
+	}
+	return &BinaryDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next record. It returns io.EOF if there are no more records
+// and io.ErrUnexpectedEOF if the stream ends within a record.
+func (d *BinaryDecoder) Decode() (*Record, error) {
+	size, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxBinaryRecordSize {
+		return nil, fmt.Errorf("Binary record of %d bytes exceeds the maximum size", size)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(d.r, body); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	rec := new(Record)
+	if err := rec.UnmarshalBinary(body); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// appendBinaryRecord appends rec in the binary record encoding to b.
+func appendBinaryRecord(b []byte, rec *Record) []byte {
+	b = append(b, binaryVersion)
+	b = binary.AppendVarint(b, int64(rec.Level))
+	b = appendBinaryTime(b, rec.Time)
+	b = appendBinaryString(b, rec.Prefix)
+	b = appendBinaryString(b, rec.Message)
+	b = appendBinaryString(b, rec.Stack)
+	b = appendBinaryString(b, rec.Caller.Function)
+	b = appendBinaryString(b, rec.Caller.File)
+	b = binary.AppendUvarint(b, uint64(rec.Caller.Line))
+	b = binary.AppendUvarint(b, uint64(len(rec.Fields)))
+	for _, f := range rec.Fields {
+		b = appendBinaryString(b, f.Key)
+		b = appendBinaryValue(b, f.Value)
+	}
+	return b
+}
+
+// appendBinaryString appends s preceded by its length to b.
+func appendBinaryString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// appendBinaryTime appends t to b, the zero time is encoded as a single byte.
+func appendBinaryTime(b []byte, t time.Time) []byte {
+	if t.IsZero() {
+		return append(b, 0)
+	}
+	b = append(b, 1)
+	b = binary.AppendVarint(b, t.Unix())
+	return binary.AppendUvarint(b, uint64(t.Nanosecond()))
+}
+
+// appendBinaryValue appends the field value v preceded by its type tag to b.
+func appendBinaryValue(b []byte, v any) []byte {
+	switch v := resolveValue(v).(type) {
+	case nil:
+		return append(b, binaryNil)
+	case string:
+		return appendBinaryString(append(b, binaryString), v)
+	case bool:
+		if v {
+			return append(b, binaryBool, 1)
+		}
+		return append(b, binaryBool, 0)
+	case time.Duration:
+		return binary.AppendVarint(append(b, binaryDuration), int64(v))
+	case time.Time:
+		return appendBinaryTime(append(b, binaryTime), v)
+	case int:
+		return binary.AppendVarint(append(b, binaryInt), int64(v))
+	case int8:
+		return binary.AppendVarint(append(b, binaryInt), int64(v))
+	case int16:
+		return binary.AppendVarint(append(b, binaryInt), int64(v))
+	case int32:
+		return binary.AppendVarint(append(b, binaryInt), int64(v))
+	case int64:
+		return binary.AppendVarint(append(b, binaryInt), v)
+	case uint:
+		return binary.AppendUvarint(append(b, binaryUint), uint64(v))
+	case uint8:
+		return binary.AppendUvarint(append(b, binaryUint), uint64(v))
+	case uint16:
+		return binary.AppendUvarint(append(b, binaryUint), uint64(v))
+	case uint32:
+		return binary.AppendUvarint(append(b, binaryUint), uint64(v))
+	case uint64:
+		return binary.AppendUvarint(append(b, binaryUint), v)
+	case float32:
+		return binary.LittleEndian.AppendUint64(append(b, binaryFloat), math.Float64bits(float64(v)))
+	case float64:
+		return binary.LittleEndian.AppendUint64(append(b, binaryFloat), math.Float64bits(v))
+	default:
+		return appendBinaryString(append(b, binaryString), fieldValueString(v))
+	}
+}
+
+// binaryReader decodes the parts of a binary record. After the first error, all methods return zero values.
+type binaryReader struct {
+	b   []byte
+	err error
+}
+
+func (d *binaryReader) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.b) < 1 {
+		d.err = errBinaryRecord
+		return 0
+	}
+	c := d.b[0]
+	d.b = d.b[1:]
+	return c
+}
+
+func (d *binaryReader) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.b)
+	if n <= 0 {
+		d.err = errBinaryRecord
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *binaryReader) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.err = errBinaryRecord
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *binaryReader) string() string {
+	size := d.uvarint()
+	if d.err != nil {
+		return ""
+	}
+	if size > uint64(len(d.b)) {
+		d.err = errBinaryRecord
+		return ""
+	}
+	s := string(d.b[:size])
+	d.b = d.b[size:]
+	return s
+}
+
+func (d *binaryReader) time() time.Time {
+	if d.byte() == 0 {
+		return time.Time{}
+	}
+	sec := d.varint()
+	nsec := d.uvarint()
+	if nsec >= uint64(time.Second) {
+		d.err = errBinaryRecord
+	}
+	if d.err != nil {
+		return time.Time{}
+	}
+	return time.Unix(sec, int64(nsec))
+}
+
+func (d *binaryReader) value() any {
+	switch tag := d.byte(); tag {
+	case binaryNil:
+		return nil
+	case binaryString:
+		return d.string()
+	case binaryInt:
+		return d.varint()
+	case binaryUint:
+		return d.uvarint()
+	case binaryFloat:
+		if len(d.b) < 8 {
+			d.err = errBinaryRecord
+			return nil
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.b))
+		d.b = d.b[8:]
+		return v
+	case binaryBool:
+		return d.byte() != 0
+	case binaryTime:
+		return d.time()
+	case binaryDuration:
+		return time.Duration(d.varint())
+	}
+	d.err = errBinaryRecord
+	return nil
+}
@@ -0,0 +1,63 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBinaryEncoding(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 123, time.UTC)
+	buf := new(bytes.Buffer)
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	l.SetClock(func() time.Time { return now })
+	l.AddSink(NewBinaryEncoder(buf), LevelDebug)
+	l.WithPrefix("db").InfoKV("query", "rows", 3, "ok", true, "took", time.Second, "ratio", 0.5,
+		"size", uint8(7), "err", errors.New("failed"), "none", nil)
+	l.Warning("second")
+	dec := NewBinaryDecoder(buf)
+	rec, err := dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rec.Time.Equal(now) || rec.Level != LevelInfo || rec.Prefix != "db" || rec.Message != "query" {
+		t.Errorf("Unexpected record %+v", rec)
+	}
+	expected := []Field{{"rows", int64(3)}, {"ok", true}, {"took", time.Second}, {"ratio", 0.5},
+		{"size", uint64(7)}, {"err", "failed"}, {"none", nil}}
+	if !reflect.DeepEqual(rec.Fields, expected) {
+		t.Errorf("Expected %v. Got %v", expected, rec.Fields)
+	}
+	if rec, err = dec.Decode(); err != nil || rec.Message != "second" || rec.Level != LevelWarning {
+		t.Errorf("Unexpected record %+v, %v", rec, err)
+	}
+	if _, err = dec.Decode(); err != io.EOF {
+		t.Errorf("Expected %v. Got %v", io.EOF, err)
+	}
+}
+
+func TestBinaryRecordMalformed(t *testing.T) {
+	rec := &Record{Level: LevelError, Message: "message", Fields: []Field{{"key", "value"}}}
+	b, _ := rec.MarshalBinary()
+	for i := 0; i < len(b); i++ {
+		if err := new(Record).UnmarshalBinary(b[:i]); err == nil {
+			t.Errorf("Expected an error for %d of %d bytes", i, len(b))
+		}
+	}
+	var decoded Record
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, rec) {
+		t.Errorf("Expected %+v. Got %+v", rec, &decoded)
+	}
+	truncated := NewBinaryDecoder(bytes.NewReader([]byte{10, 1, 2}))
+	if _, err := truncated.Decode(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected %v. Got %v", io.ErrUnexpectedEOF, err)
+	}
+}
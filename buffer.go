@@ -0,0 +1,32 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import "sync"
+
+// maxPooledBuffer is the capacity up to which buffers are returned to the pool,
+// larger buffers are left to the garbage collector so single huge records don't pin memory.
+const maxPooledBuffer = 64 << 10
+
+// bufferPool holds the buffers records are encoded into.
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 512)
+		return &b
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *[]byte {
+	b := bufferPool.Get().(*[]byte)
+	*b = (*b)[:0]
+	return b
+}
+
+// putBuffer returns b to the pool. b must not be used afterwards.
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(b)
+}
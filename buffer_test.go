@@ -0,0 +1,155 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBufferReuse(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.InfoKV("first record", "key", "a long value that does not fit a later record")
+	l.Info("second")
+	expected := "[Info] - first record - key=\"a long value that does not fit a later record\"\n[Info] - second\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestBufferConcurrent(t *testing.T) {
+	b := new(syncBuilder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.InfoKV("msg", "n", j)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		if !strings.HasPrefix(line, "[Info] - msg - n=") {
+			t.Fatalf("Corrupted record %q", line)
+		}
+	}
+}
+
+func BenchmarkPrintln(b *testing.B) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("The quick brown fox jumps over the lazy dog")
+	}
+}
+
+func BenchmarkPrintf(b *testing.B) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Infof("The quick brown %s jumps over the lazy %s", "fox", "dog")
+	}
+}
+
+func BenchmarkPrintKV(b *testing.B) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.InfoKV("request", "method", "GET", "status", 200)
+	}
+}
+
+func BenchmarkPrintJSON(b *testing.B) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	l.SetFormat(FormatJSON)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.InfoKV("request", "method", "GET", "status", 200)
+	}
+}
+
+func BenchmarkPrintlnParallel(b *testing.B) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info("The quick brown fox jumps over the lazy dog")
+		}
+	})
+}
+
+func BenchmarkPrintlnParallelAsync(b *testing.B) {
+	l := NewAsync(io.Discard, LevelInfo, loglevelDelimiter, 1024)
+	defer l.Close()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info("The quick brown fox jumps over the lazy dog")
+		}
+	})
+}
+
+func BenchmarkPrintlnParallelSharded(b *testing.B) {
+	l := NewSharded(io.Discard, LevelInfo, loglevelDelimiter, 8, time.Millisecond)
+	defer l.Close()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info("The quick brown fox jumps over the lazy dog")
+		}
+	})
+}
+
+func BenchmarkEncode(b *testing.B) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	for _, format := range []Format{FormatText, FormatJSON, FormatCSV} {
+		b.Run(format.String(), func(b *testing.B) {
+			buf := make([]byte, 0, 1024)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf, _ = l.encode(buf[:0], &benchRecord, format)
+			}
+		})
+	}
+}
+
+// benchRecord is the record the encoding benchmarks render.
+var benchRecord = Record{
+	Time:    time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC),
+	Level:   LevelInfo,
+	Prefix:  "http",
+	Message: "The quick brown fox jumps over the lazy dog",
+	Fields:  []Field{{"method", "GET"}, {"status", 200}, {"path", "/index.html"}},
+}
+
+func TestAllocs(t *testing.T) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	tests := []struct {
+		name string
+		max  float64
+		f    func()
+	}{
+		{"Filtered", 0, func() { l.Debug("filtered") }},
+		{"FilteredPrintf", 0, func() { l.Debugf("filtered %d", 1) }},
+		{"FilteredKV", 0, func() { l.DebugKV("filtered", "key", "value") }},
+		{"Println", 1, func() { l.Info("The quick brown fox jumps over the lazy dog") }},
+	}
+	for _, test := range tests {
+		if allocs := testing.AllocsPerRun(100, test.f); allocs > test.max {
+			t.Errorf("%s: Expected at most %.0f allocations, got %.0f", test.name, test.max, allocs)
+		}
+	}
+	b := make([]byte, 0, 1024)
+	for _, format := range []Format{FormatText, FormatCSV} {
+		if allocs := testing.AllocsPerRun(100, func() { b, _ = l.encode(b[:0], &benchRecord, format) }); allocs > 0 {
+			t.Errorf("Encoding %s: Expected no allocations, got %.0f", format, allocs)
+		}
+	}
+}
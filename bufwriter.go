@@ -0,0 +1,113 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Defaults of a BufferedWriter.
+const (
+	defaultBufferSize    = 64 << 10
+	defaultFlushInterval = time.Second
+)
+
+// BufferedWriter is an io.WriteCloser that buffers records in memory and writes them to the underlying writer
+// in large chunks, so high-volume logging to a file does not need a system call per record. Buffered data is
+// written once the buffer is full, at the latest after the flush interval and when Flush or Close is called.
+// A Logger flushes its BufferedWriters in Flush, Close, Die and Dief. If writing the buffer fails, the buffered data
+// is discarded and the error is returned, so the BufferedWriter keeps working once the underlying writer recovers.
+// A BufferedWriter can be used by multiple goroutines.
+type BufferedWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	buf      []byte
+	size     int
+	interval time.Duration
+	timer    *time.Timer // Pending periodic flush, nil if the buffer is empty.
+	closed   bool
+}
+
+// NewBufferedWriter returns a BufferedWriter that writes to w through a buffer of size bytes and flushes the buffer
+// interval after data has been written to the empty buffer. A size or interval of 0 selects the defaults of 64 KiB and 1s.
+func NewBufferedWriter(w io.Writer, size int, interval time.Duration) *BufferedWriter {
+	if w == nil {
+		panic("Programming error: logger.NewBufferedWriter: Passed nil as writer")
+	}
+	if size < 0 || interval < 0 {
+		panic("Programming error: logger.NewBufferedWriter: Passed negative size or interval")
+	}
+	setDefault(&size, defaultBufferSize)
+	setDefault(&interval, defaultFlushInterval)
+	return &BufferedWriter{w: w, buf: make([]byte, 0, size), size: size, interval: interval}
+}
+
+// Close flushes the buffer, stops the periodic flush and closes the underlying writer if it implements io.Closer.
+// Writing to a closed BufferedWriter returns ErrClosed.
+func (b *BufferedWriter) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	err := b.flush()
+	if c, ok := b.w.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Flush writes the buffered data to the underlying writer.
+func (b *BufferedWriter) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.flush()
+}
+
+// Write appends p to the buffer. If p does not fit, the buffer is written to the underlying writer first.
+// If p is larger than the buffer, it is written to the underlying writer directly.
+func (b *BufferedWriter) Write(p []byte) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return 0, ErrClosed
+	}
+	if len(b.buf)+len(p) > b.size {
+		if err := b.flush(); err != nil {
+			return 0, err
+		}
+	}
+	if len(p) >= b.size {
+		return b.w.Write(p)
+	}
+	b.buf = append(b.buf, p...)
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.interval, func() {
+			b.Flush()
+		})
+	}
+	return len(p), nil
+}
+
+// flush writes the buffered data and stops the pending periodic flush. The buffer is emptied even if the write fails,
+// the error is returned instead. The caller must hold b.mu.
+func (b *BufferedWriter) flush() error {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.buf) < 1 {
+		return nil
+	}
+	n, err := b.w.Write(b.buf)
+	if err == nil && n < len(b.buf) {
+		err = io.ErrShortWrite
+	}
+	b.buf = b.buf[:0]
+	return err
+}
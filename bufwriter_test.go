@@ -0,0 +1,68 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBufferedWriter(t *testing.T) {
+	b := new(syncBuilder)
+	w := NewBufferedWriter(b, 1024, time.Hour)
+	l := New(w, LevelInfo, loglevelDelimiter)
+	l.Info("buffered")
+	if b.String() != "" {
+		t.Errorf("Expected the record to be buffered. Got %q", b.String())
+	}
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "[Info] - buffered\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - buffered\n", b.String())
+	}
+	l.Info(strings.Repeat("x", 2048))
+	if !strings.Contains(b.String(), strings.Repeat("x", 2048)) {
+		t.Error("Expected a record larger than the buffer to be written immediately")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("late")); err != ErrClosed {
+		t.Errorf("Expected %v. Got %v", ErrClosed, err)
+	}
+}
+
+func TestBufferedWriterInterval(t *testing.T) {
+	b := new(syncBuilder)
+	l := New(NewBufferedWriter(b, 0, 10*time.Millisecond), LevelInfo, loglevelDelimiter)
+	l.Info("periodic")
+	deadline := time.Now().Add(5 * time.Second)
+	for b.String() == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("The buffer was not flushed after the interval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if b.String() != "[Info] - periodic\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - periodic\n", b.String())
+	}
+}
+
+func TestBufferedWriterRecovers(t *testing.T) {
+	f := &flakyWriter{failures: 1}
+	w := NewBufferedWriter(f, 1024, time.Hour)
+	l := New(w, LevelInfo, loglevelDelimiter)
+	l.Info("lost")
+	if err := l.Flush(); err == nil {
+		t.Error("Expected the error of the underlying writer")
+	}
+	l.Info("recovered")
+	if err := l.Flush(); err != nil {
+		t.Fatalf("Expected the BufferedWriter to recover. Got %v", err)
+	}
+	if f.b.String() != "[Info] - recovered\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - recovered\n", f.b.String())
+	}
+}
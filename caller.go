@@ -0,0 +1,107 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxCallerDepth is the maximum number of stack frames that are inspected to find the caller of a Logger.
+const maxCallerDepth = 32
+
+// callerSkipPrefixes holds the prefixes of the functions that are skipped when determining the caller of a record:
+// methods with pointer receivers in this package, the functions of the standard library's log package and
+// the runtime's functions, which are on the stack when a recovered panic is logged.
+var callerSkipPrefixes = []string{reflect.TypeOf(Logger{}).PkgPath() + ".(*", "log.", "runtime."}
+
+// callerSkipFunctions holds the names of additional functions that are skipped when determining the caller of a record.
+var callerSkipFunctions = make(map[string]bool)
+
+// CallerSkip returns the number of additional stack frames that are skipped when determining the caller of a record.
+func (l *Logger) CallerSkip() int {
+	defer l.runlock(l.rlock())
+	return l.callerSkip
+}
+
+// ReportCaller returns true if the Logger adds caller information to its records.
+func (l *Logger) ReportCaller() bool {
+	defer l.runlock(l.rlock())
+	return l.reportCaller
+}
+
+// SetCallerSkip sets the number of stack frames that are skipped in addition to the Logger's own methods
+// when determining the caller of a record. Wrappers around a Logger set this to their own call depth
+// so the records point to the code that called the wrapper. A negative skip will cause a panic.
+func (l *Logger) SetCallerSkip(skip int) {
+	if skip < 0 {
+		panic("Programming error: (l *Logger) SetCallerSkip(): Passed a negative skip")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.callerSkip = skip
+	l.snapshotShards()
+}
+
+// SetReportCaller enables or disables caller information in the Logger's records. If enabled, every record
+// shows the file name and line of the code that logged it. Records passed to Output that already have caller
+// information keep it.
+func (l *Logger) SetReportCaller(enable bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reportCaller = enable
+	l.snapshotShards()
+}
+
+// caller returns the first stack frame outside of the logging machinery, skipping the configured
+// number of additional frames. The caller must hold the Logger's lock.
+func (l *Logger) caller() runtime.Frame {
+	var pcs [maxCallerDepth]uintptr
+	return callerOf(pcs[:runtime.Callers(2, pcs[:])], l.callerSkip)
+}
+
+// callerOf returns the first stack frame described by the program counters pcs that is outside of the logging
+// machinery, skipping skip additional frames.
+func callerOf(pcs []uintptr, skip int) runtime.Frame {
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !skipFrame(frame.Function) {
+			if skip < 1 {
+				return frame
+			}
+			skip--
+		}
+		if !more {
+			return runtime.Frame{}
+		}
+	}
+}
+
+// skipFunctions adds the functions fns to the functions that are skipped when determining the caller of a record.
+func skipFunctions(fns ...any) {
+	for _, fn := range fns {
+		callerSkipFunctions[runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()] = true
+	}
+}
+
+// skipFrame returns true if function is part of the logging machinery.
+func skipFrame(function string) bool {
+	if callerSkipFunctions[function] {
+		return true
+	}
+	for _, prefix := range callerSkipPrefixes {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// callerString returns the caller information of rec as "file:line", file being the base name of the source file.
+func callerString(rec *Record) string {
+	return filepath.Base(rec.Caller.File) + ":" + strconv.Itoa(rec.Caller.Line)
+}
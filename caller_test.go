@@ -0,0 +1,53 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// lineOfCaller returns the line number its caller was called from.
+func lineOfCaller() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func logViaWrapper(l *Logger, msg string) {
+	l.Infof("%s", msg)
+}
+
+func TestReportCaller(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetReportCaller(true)
+	// Each function logs a record and returns the line it was logged from.
+	for _, log := range []func() int{
+		func() int { l.Info("msg"); return lineOfCaller() },
+		func() int { l.Println(LevelInfo, "msg"); return lineOfCaller() },
+		func() int { l.InfoKV("msg"); return lineOfCaller() },
+		func() int { l.Output(Record{Level: LevelInfo, Message: "msg"}); return lineOfCaller() },
+	} {
+		line := log()
+		expect := fmt.Sprintf("[Info]%scaller_test.go:%d%smsg\n", loglevelDelimiter, line, loglevelDelimiter)
+		if b.String() != expect {
+			t.Errorf("Expected %q. Got %q", expect, b.String())
+		}
+		b.Reset()
+	}
+}
+
+func TestCallerSkip(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetReportCaller(true)
+	l.SetCallerSkip(1)
+	_, _, line, _ := runtime.Caller(0)
+	logViaWrapper(l, "msg")
+	expect := fmt.Sprintf("[Info]%scaller_test.go:%d%smsg\n", loglevelDelimiter, line+1, loglevelDelimiter)
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
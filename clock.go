@@ -0,0 +1,32 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import "time"
+
+// SetClock sets the function the Logger uses to timestamp its records. Tests can pass a function that returns
+// fixed times to produce deterministic output, programs may pass a cheaper coarse clock. The time elapsed since
+// the Logger's creation, see TimeElapsed, is measured from the first reading of the new clock.
+// Passing nil restores time.Now.
+func (l *Logger) SetClock(clock func() time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if clock == nil {
+		l.clock.Store(nil)
+	} else {
+		l.clock.Store(&clock)
+	}
+	l.created = l.now()
+}
+
+// now returns the current time according to the Logger's clock. It does not need the Logger's lock,
+// so PrintEvery and TimeTrack can read the clock from within hooks and writers.
+func (l *Logger) now() time.Time {
+	if l.deterministic.Load() {
+		return deterministicTime
+	}
+	if clock := l.clock.Load(); clock != nil {
+		return (*clock)()
+	}
+	return time.Now()
+}
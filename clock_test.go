@@ -0,0 +1,31 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetClock(t *testing.T) {
+	ts := time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetTimeFormat(time.DateTime)
+	l.SetClock(func() time.Time {
+		ts = ts.Add(time.Second)
+		return ts
+	})
+	l.Info("first")
+	l.SetTimeStyle(TimeElapsed)
+	l.Info("second")
+	expected := "[Info] - 2023-05-01 12:30:02 - first\n[Info] - 2.000000 - second\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	l.SetClock(nil)
+	if l.created.Before(time.Now().Add(-time.Minute)) {
+		t.Errorf("Expected the creation time to be reset to the current time. Got %s", l.created)
+	}
+}
@@ -0,0 +1,128 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Clone returns an independent Logger with the settings of l, which makes it easy to derive loggers for subsystems
+// from a configured template. Unlike a child logger created by WithPrefix or WithFields, the clone has its own lock,
+// and changing the settings of either Logger does not affect the other one. The clone writes to the same writers and
+// sinks and uses the same hooks and sampler as l, closing it closes these writers too. Its statistics start at zero,
+// pending repetitions of a deduplicating Logger and the records kept by a flight recorder are not carried over.
+// The clone of an asynchronous or sharded Logger gets its own background goroutine.
+func (l *Logger) Clone() *Logger {
+	return l.clone(nil)
+}
+
+// CloneWithOutput returns a clone of l like Clone that writes to w instead of the Logger's writer, see SetOutput.
+// Outputs added by AddOutput or SetLevelOutput are kept. Passing nil as w will cause a panic.
+func (l *Logger) CloneWithOutput(w io.Writer) *Logger {
+	if w == nil {
+		panic("Programming error: (l *Logger) CloneWithOutput(): Passed nil as output writer")
+	}
+	return l.clone(w)
+}
+
+// clone implements Clone and CloneWithOutput. If w is nil, the clone writes to the Logger's writer.
+func (l *Logger) clone(w io.Writer) *Logger {
+	l.mu.RLock()
+	c := &core{
+		mu:              new(sync.RWMutex),
+		reentry:         new(reentrancy),
+		throttle:        new(throttle),
+		delimiter:       l.delimiter,
+		timeFormat:      l.timeFormat,
+		timeStyle:       l.timeStyle,
+		created:         l.created,
+		format:          l.format,
+		out:             l.out,
+		outputs:         l.outputs[:len(l.outputs):len(l.outputs)],
+		levelOutputs:    make(map[Level]io.Writer, len(l.levelOutputs)),
+		sinks:           l.sinks[:len(l.sinks):len(l.sinks)],
+		reportCaller:    l.reportCaller,
+		callerSkip:      l.callerSkip,
+		stacktraceLevel: l.stacktraceLevel,
+		extractor:       l.extractor,
+		color:           l.color,
+		colorize:        l.colorize,
+		exitHooks:       l.exitHooks[:len(l.exitHooks):len(l.exitHooks)],
+		exitFunc:        l.exitFunc,
+		exitCode:        l.exitCode,
+		deferredExit:    l.deferredExit,
+		crashFile:       l.crashFile,
+		hooks:           make(map[Level][]Hook, len(l.hooks)),
+		sampler:         l.sampler,
+		errorHandler:    l.errorHandler,
+		errorPolicy:     l.errorPolicy,
+		writeTimeout:    l.writeTimeout,
+		maxLength:       l.maxLength,
+		lengthPolicy:    l.lengthPolicy,
+		layout:          l.layout[:len(l.layout):len(l.layout)],
+		template:        l.template,
+		padLevel:        l.padLevel,
+		levelNameStyle:  l.levelNameStyle,
+		decorations:     make(map[Level]string, len(l.decorations)),
+		transformers:    make(map[Level][]Transformer, len(l.transformers)),
+		redactors:       l.redactors[:len(l.redactors):len(l.redactors)],
+		filters:         l.filters[:len(l.filters):len(l.filters)],
+		secretPolicy:    l.secretPolicy,
+		escapeDelimiter: l.escapeDelimiter,
+		includeHostname: l.includeHostname,
+		includePID:      l.includePID,
+		groupStyle:      l.groupStyle,
+		discard:         l.discard,
+	}
+	c.assertAction.Store(l.assertAction.Load())
+	c.clock.Store(l.clock.Load())
+	c.deterministic.Store(l.deterministic.Load())
+	for lvl, out := range l.levelOutputs {
+		c.levelOutputs[lvl] = out
+	}
+	for lvl, hooks := range l.hooks {
+		c.hooks[lvl] = hooks[:len(hooks):len(hooks)]
+	}
+	for lvl, d := range l.decorations {
+		c.decorations[lvl] = d
+	}
+	for lvl, transformers := range l.transformers {
+		c.transformers[lvl] = transformers[:len(transformers):len(transformers)]
+	}
+	if l.dedup != nil {
+		c.dedup = &dedup{window: l.dedup.window}
+	}
+	if l.diskGuard != nil {
+		c.diskGuard = &diskGuard{DiskGuard: l.diskGuard.DiskGuard}
+	}
+	if l.recorder != nil {
+		c.recorder = &flightRecorder{recs: make([]Record, 0, cap(l.recorder.recs)), threshold: l.recorder.threshold}
+		c.recording.Store(true)
+	}
+	async, queueSize := l.async != nil, 0
+	if async {
+		queueSize = cap(l.async.items)
+	}
+	var shards int
+	var interval time.Duration
+	if l.shards != nil {
+		shards, interval = len(l.shards.shards), l.shards.interval
+	}
+	level := &loglevel{listeners: l.level.listeners[:len(l.level.listeners):len(l.level.listeners)]}
+	level.Store(l.level.Load())
+	cl := &Logger{core: c, level: level, prefix: l.prefix, fields: l.fields}
+	l.mu.RUnlock()
+	if w != nil {
+		cl.out = w
+		cl.updateColorize()
+	}
+	if async {
+		cl.startAsync(queueSize)
+	}
+	if shards > 0 {
+		cl.startShards(shards, interval)
+	}
+	return cl
+}
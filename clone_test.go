@@ -0,0 +1,53 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetFormat(FormatJSON)
+	l.AddHook(&testHook{})
+	c := l.WithPrefix("db").Clone()
+	if c.Level() != LevelInfo || c.Format() != FormatJSON || c.Prefix() != "db" {
+		t.Errorf("Clone has different settings: level %s, format %s, prefix %q", c.Level(), c.Format(), c.Prefix())
+	}
+	c.SetLevel(LevelDebug)
+	c.SetFormat(FormatText)
+	hook := new(testHook)
+	c.AddHook(hook)
+	if l.Level() != LevelInfo || l.Format() != FormatJSON {
+		t.Errorf("Changing the clone changed the original: level %s, format %s", l.Level(), l.Format())
+	}
+	l.Warning("original")
+	c.Debug("clone")
+	if hook.fired != 0 {
+		t.Errorf("Hook added to the clone was fired %d times by the original", hook.fired)
+	}
+	if !strings.HasPrefix(b.String(), `{"`) || !strings.HasSuffix(b.String(), "[Debug] - db: clone\n") {
+		t.Errorf("Unexpected output %q", b.String())
+	}
+}
+
+func TestCloneWithOutput(t *testing.T) {
+	b, cb := new(strings.Builder), new(strings.Builder)
+	for _, l := range []*Logger{New(b, LevelInfo, loglevelDelimiter), NewAsync(b, LevelInfo, loglevelDelimiter, 4)} {
+		b.Reset()
+		cb.Reset()
+		c := l.CloneWithOutput(cb)
+		l.Info("original")
+		c.Info("clone")
+		l.Close()
+		c.Close()
+		if b.String() != "[Info] - original\n" || cb.String() != "[Info] - clone\n" {
+			t.Errorf("Unexpected output %q and %q", b.String(), cb.String())
+		}
+		if !l.Closed() || !c.Closed() {
+			t.Error("Logger was not closed")
+		}
+	}
+}
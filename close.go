@@ -0,0 +1,46 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// Close shuts the Logger down. It writes the records queued by an asynchronous Logger or buffered by a sharded Logger
+// and stops its background goroutine, reports pending repetitions of a deduplicating Logger, flushes buffering writers and closes
+// the writers the Logger opened itself, like the file opened by Config.Build. Writers and sinks passed by the caller are not
+// closed, the caller stays responsible for them. Records sent to the Logger afterwards are
+// discarded and ErrClosed is returned, see Dropped. Close affects all Loggers derived from the same Logger.
+// Calling Close again has no effect. The first error that occurred is returned.
+func (l *Logger) Close() error {
+	if !l.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	if l.async != nil {
+		l.closeAsync()
+	}
+	if l.shards != nil {
+		l.closeShards()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.dedup != nil {
+		if summary := l.repeatSummary(); summary != nil {
+			l.write(summary)
+		}
+	}
+	err := l.flushWriters()
+	for _, c := range l.owned {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Closed returns true if the Logger has been closed.
+func (l *Logger) Closed() bool {
+	return l.closed.Load()
+}
+
+// Dropped returns the number of records the Logger discarded because they were sent after Close, because
+// a write exceeded the write timeout, see SetWriteTimeout, or because their message was too long, see SetMaxLength.
+func (l *Logger) Dropped() uint64 {
+	return l.dropped.Load()
+}
@@ -0,0 +1,69 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// closeRecorder is a writer and sink that records whether it has been closed.
+type closeRecorder struct {
+	bytes.Buffer
+	closed int
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed++
+	return nil
+}
+
+func (c *closeRecorder) WriteRecord(rec *Record) error {
+	return nil
+}
+
+func TestClose(t *testing.T) {
+	out, extra, sink := new(closeRecorder), new(closeRecorder), new(closeRecorder)
+	buffered := NewBufferedWriter(out, 0, time.Hour)
+	l := New(buffered, LevelInfo, loglevelDelimiter)
+	l.AddOutput(extra, LevelInfo)
+	l.SetLevelOutput(LevelError, extra)
+	l.AddSink(sink, LevelInfo)
+	l.SetDedupWindow(time.Hour)
+	l.Info("repeated")
+	l.Info("repeated")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "[Info] - repeated\n[Info] - last message repeated 1 times\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, out.String())
+	}
+	if out.closed != 0 || extra.closed != 0 || sink.closed != 0 {
+		t.Errorf("Expected the caller's writers and sinks to stay open. Got %d, %d and %d closes", out.closed, extra.closed, sink.closed)
+	}
+	if _, err := l.Info("after close"); err != ErrClosed || l.Dropped() != 1 || !l.Closed() {
+		t.Errorf("Expected %v and 1 dropped record. Got %v and %d", ErrClosed, err, l.Dropped())
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Expected a second Close to have no effect. Got %v", err)
+	}
+}
+
+func TestCloseOwned(t *testing.T) {
+	l, err := (&Config{Output: filepath.Join(t.TempDir(), "test.log")}).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := l.out.(*os.File)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("after close\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected %v. Got %v", os.ErrClosed, err)
+	}
+}
@@ -0,0 +1,160 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	ColorNever  ColorMode = iota //Never colorize records.
+	ColorAuto                    //Colorize records if the Logger's writer is a terminal and the environment variable NO_COLOR is not set.
+	ColorAlways                  //Always colorize records.
+)
+
+// ANSI escape sequences used for colorizing records.
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBoldRed = "\x1b[1;31m"
+	ansiRed     = "\x1b[31m"
+	ansiYellow  = "\x1b[33m"
+	ansiCyan    = "\x1b[36m"
+	ansiDim     = "\x1b[2m"
+)
+
+// Represents the setting that determines whether a Logger colorizes its records.
+type ColorMode int
+
+// Panics if the color mode does not exist.
+func assertColorMode(mode ColorMode) {
+	if err := checkColorMode(mode); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Returns an error if the color mode does not exist.
+func checkColorMode(mode ColorMode) error {
+	if mode < ColorNever || mode > ColorAlways {
+		return fmt.Errorf("Color mode %d is not defined", mode)
+	}
+	return nil
+}
+
+// String returns the string representation of a ColorMode. If the ColorMode is
+// not defined, String returns "Undefined".
+func (m ColorMode) String() string {
+	switch m {
+	case ColorNever:
+		return "Never"
+	case ColorAuto:
+		return "Auto"
+	case ColorAlways:
+		return "Always"
+	}
+	return "Undefined"
+}
+
+// ParseColorMode returns the ColorMode whose string representation matches input, ignoring case.
+// On failure, it returns ColorNever and an error.
+func ParseColorMode(input string) (ColorMode, error) {
+	switch strings.ToLower(input) {
+	case "never":
+		return ColorNever, nil
+	case "auto":
+		return ColorAuto, nil
+	case "always":
+		return ColorAlways, nil
+	}
+	return ColorNever, fmt.Errorf("Input sequence %q cannot be associated with a defined color mode", input)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the string representation of the ColorMode,
+// or an error if the ColorMode is not defined.
+func (m ColorMode) MarshalText() ([]byte, error) {
+	if err := checkColorMode(m); err != nil {
+		return nil, err
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all input ParseColorMode accepts.
+func (m *ColorMode) UnmarshalText(text []byte) error {
+	mode, err := ParseColorMode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+// isTerminal returns true if w is a character device like a terminal or a ProgressWriter that writes to one.
+func isTerminal(w io.Writer) bool {
+	if p, ok := w.(*ProgressWriter); ok {
+		return p.terminal
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// levelColor returns the ANSI escape sequence that colorizes the level tag of a record.
+// It returns "" for levels that are not colorized.
+func levelColor(level Level) string {
+	switch level {
+	case LevelPanic, LevelAlert, LevelCritical:
+		return ansiBoldRed
+	case LevelError:
+		return ansiRed
+	case LevelWarning:
+		return ansiYellow
+	case LevelNotice:
+		return ansiCyan
+	case LevelDebug, LevelTrace:
+		return ansiDim
+	}
+	return ""
+}
+
+// Color returns the Logger's color setting.
+func (l *Logger) Color() ColorMode {
+	defer l.runlock(l.rlock())
+	return l.color
+}
+
+// SetColor sets whether the Logger colorizes the level tags of its records with ANSI escape sequences,
+// errors are printed red, warnings yellow and debug records dim. With ColorAuto, the Logger's writer
+// is checked each time it is set. Colors only apply to FormatText, outputs added by AddOutput receive the same
+// colorization as the Logger's writer. Setting an invalid color mode will cause a panic.
+func (l *Logger) SetColor(mode ColorMode) {
+	assertColorMode(mode)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.color = mode
+	l.updateColorize()
+}
+
+// updateColorize determines whether records have to be colorized. The caller must hold the Logger's lock.
+func (l *Logger) updateColorize() {
+	if l.deterministic.Load() {
+		l.colorize = false
+		return
+	}
+	switch l.color {
+	case ColorAlways:
+		l.colorize = true
+	case ColorAuto:
+		_, noColor := os.LookupEnv("NO_COLOR")
+		l.colorize = !noColor && isTerminal(l.out)
+	default:
+		l.colorize = false
+	}
+}
@@ -0,0 +1,24 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetColor(ColorAuto)
+	l.Error("no terminal")
+	l.SetColor(ColorAlways)
+	l.Error("error")
+	l.Info("info")
+	expect := "[Error] - no terminal\n" +
+		ansiRed + "[Error]" + ansiReset + " - error\n" +
+		"[Info] - info\n"
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
@@ -0,0 +1,132 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+	"time"
+)
+
+// Compressor is a streaming compression encoder that writes compressed data to an underlying writer.
+// *gzip.Writer and *zlib.Writer implement it, as do the zstd encoders of common third-party packages.
+type Compressor interface {
+	io.WriteCloser
+	Flush() error // Writes all pending data to the underlying writer.
+}
+
+// CompressedWriter is an io.WriteCloser that compresses records before they reach a file or network connection,
+// which makes archiving verbose logs cheap. Compressed data is flushed to the underlying writer at the latest after
+// the flush interval and when Flush or Close is called, after a flush the underlying writer is synced if it has a
+// method Sync() error, like *os.File. Like a BufferedWriter, it is flushed by the Logger
+// that writes to it. A CompressedWriter can be used by multiple goroutines.
+type CompressedWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	c        Compressor
+	interval time.Duration
+	timer    *time.Timer // Pending periodic flush, nil if nothing was written since the last flush.
+	closed   bool
+}
+
+// NewGzipWriter returns a CompressedWriter that compresses to w with gzip at the given compression level,
+// see the constants of package compress/gzip. An interval of 0 flushes every second.
+// An error is returned if level is invalid. Passing nil as w or a negative interval will cause a panic.
+func NewGzipWriter(w io.Writer, level int, interval time.Duration) (*CompressedWriter, error) {
+	if w == nil {
+		panic("Programming error: logger.NewGzipWriter: Passed nil as writer")
+	}
+	zw, err := gzip.NewWriterLevel(w, level)
+	if err != nil {
+		return nil, err
+	}
+	return NewCompressedWriter(w, zw, interval), nil
+}
+
+// NewCompressedWriter returns a CompressedWriter that compresses with c, which must write to w. It can be used to
+// compress with zstd or other algorithms that are not part of the standard library. An interval of 0 flushes every
+// second. Passing nil as w or c or a negative interval will cause a panic.
+func NewCompressedWriter(w io.Writer, c Compressor, interval time.Duration) *CompressedWriter {
+	if w == nil || c == nil {
+		panic("Programming error: logger.NewCompressedWriter: Passed nil as writer or compressor")
+	}
+	if interval < 0 {
+		panic("Programming error: logger.NewCompressedWriter: Passed negative interval")
+	}
+	setDefault(&interval, defaultFlushInterval)
+	return &CompressedWriter{w: w, c: c, interval: interval}
+}
+
+// Close completes the compressed stream, stops the periodic flush and closes the underlying writer if it
+// implements io.Closer. Writing to a closed CompressedWriter returns ErrClosed.
+func (c *CompressedWriter) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.stopTimer()
+	err := c.c.Close()
+	if serr := c.sync(); err == nil {
+		err = serr
+	}
+	if cl, ok := c.w.(io.Closer); ok {
+		if cerr := cl.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Flush writes the pending compressed data to the underlying writer and syncs it.
+func (c *CompressedWriter) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	return c.flush()
+}
+
+// Write compresses p.
+func (c *CompressedWriter) Write(p []byte) (n int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, ErrClosed
+	}
+	n, err = c.c.Write(p)
+	if n > 0 && c.timer == nil {
+		c.timer = time.AfterFunc(c.interval, func() {
+			c.Flush()
+		})
+	}
+	return n, err
+}
+
+// flush flushes the compressor, syncs the underlying writer and stops the pending periodic flush. The caller must hold c.mu.
+func (c *CompressedWriter) flush() error {
+	c.stopTimer()
+	if err := c.c.Flush(); err != nil {
+		return err
+	}
+	return c.sync()
+}
+
+// stopTimer stops the pending periodic flush. The caller must hold c.mu.
+func (c *CompressedWriter) stopTimer() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+}
+
+// sync syncs the underlying writer if it has a method Sync() error. The caller must hold c.mu.
+func (c *CompressedWriter) sync() error {
+	if s, ok := c.w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+)
+
+// gunzip returns the decompressed content of b, which may lack the end of the gzip stream.
+func gunzip(b []byte) string {
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return ""
+	}
+	content, _ := io.ReadAll(zr)
+	return string(content)
+}
+
+func TestGzipWriter(t *testing.T) {
+	b := new(syncBuilder)
+	w, err := NewGzipWriter(b, gzip.BestCompression, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(w, LevelInfo, loglevelDelimiter)
+	l.Info("compressed")
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if s := gunzip([]byte(b.String())); s != "[Info] - compressed\n" {
+		t.Errorf("Expected %q after Flush. Got %q", "[Info] - compressed\n", s)
+	}
+	l.Info("closed")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if s := gunzip([]byte(b.String())); s != "[Info] - compressed\n[Info] - closed\n" {
+		t.Errorf("Expected %q after Close. Got %q", "[Info] - compressed\n[Info] - closed\n", s)
+	}
+	if _, err := w.Write([]byte("late")); err != ErrClosed {
+		t.Errorf("Expected %v. Got %v", ErrClosed, err)
+	}
+	if _, err := NewGzipWriter(b, 42, 0); err == nil {
+		t.Error("Expected an error for an invalid compression level")
+	}
+}
+
+func TestCompressedWriterInterval(t *testing.T) {
+	b := new(syncBuilder)
+	w, err := NewGzipWriter(b, gzip.DefaultCompression, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(w, LevelInfo, loglevelDelimiter)
+	defer l.Close()
+	l.Info("periodic")
+	deadline := time.Now().Add(5 * time.Second)
+	for gunzip([]byte(b.String())) == "" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if s := gunzip([]byte(b.String())); s != "[Info] - periodic\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - periodic\n", s)
+	}
+}
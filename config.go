@@ -0,0 +1,186 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// timeFormatNames maps the names of the time package's layout constants to the layouts,
+// so a Config can refer to them by name.
+var timeFormatNames = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"DateOnly":    time.DateOnly,
+	"DateTime":    time.DateTime,
+	"Kitchen":     time.Kitchen,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"Stamp":       time.Stamp,
+	"StampMicro":  time.StampMicro,
+	"StampMilli":  time.StampMilli,
+	"StampNano":   time.StampNano,
+	"TimeOnly":    time.TimeOnly,
+	"UnixDate":    time.UnixDate,
+}
+
+// Config describes a Logger declaratively. It can be decoded from JSON or, as all its types implement
+// encoding.TextUnmarshaler, from TOML or YAML by the usual libraries, and it can be read from environment
+// variables by LoadEnv. The zero value describes a Logger that writes records of LevelInfo and more severe
+// ones to os.Stderr.
+type Config struct {
+	Level      Level           `json:"level" toml:"level"`                           // Loglevel, defaults to LevelInfo.
+	Format     Format          `json:"format" toml:"format"`                         // Output format, defaults to FormatText.
+	Output     string          `json:"output" toml:"output"`                         // "stderr", "stdout" or the path of a file to append to, defaults to "stderr".
+	Delimiter  string          `json:"delimiter" toml:"delimiter"`                   // Delimiter of FormatText, defaults to " - ".
+	TimeFormat string          `json:"time_format" toml:"time_format"`               // Layout of the timestamps or the name of a layout constant of package time, e.g. "RFC3339".
+	Color      ColorMode       `json:"color" toml:"color"`                           // Colorization, defaults to ColorNever.
+	Secrets    SecretPolicy    `json:"secrets" toml:"secrets"`                       // Treatment of secret fields, defaults to SecretMask.
+	Rotation   *RotationConfig `json:"rotation,omitempty" toml:"rotation,omitempty"` // Rotation of the output file, nil disables rotation.
+}
+
+// RotationConfig describes the rotation of a Logger's output file, see RotateOptions.
+type RotationConfig struct {
+	MaxSize    int64  `json:"max_size" toml:"max_size"`       // Rotate before the file would grow beyond MaxSize bytes.
+	Interval   string `json:"interval" toml:"interval"`       // Rotate after this duration, e.g. "24h".
+	MaxBackups int    `json:"max_backups" toml:"max_backups"` // Maximum number of rotated files to keep.
+	Compress   bool   `json:"compress" toml:"compress"`       // Compress rotated files with gzip.
+}
+
+// Build constructs a Logger as described by the Config. An output file is opened for appending and closed
+// by the Logger's Close method.
+func (c *Config) Build() (*Logger, error) {
+	level := c.Level
+	if level == LevelInvalid {
+		level = LevelInfo
+	}
+	if err := checkLoglevel(level); err != nil {
+		return nil, err
+	}
+	if err := checkFormat(c.Format); err != nil {
+		return nil, err
+	}
+	if err := checkColorMode(c.Color); err != nil {
+		return nil, err
+	}
+	if err := checkSecretPolicy(c.Secrets); err != nil {
+		return nil, err
+	}
+	delimiter := c.Delimiter
+	if len(delimiter) < 1 {
+		delimiter = defaultDelimiter
+	}
+	timeFormat := c.TimeFormat
+	if layout, ok := timeFormatNames[timeFormat]; ok {
+		timeFormat = layout
+	}
+	w, err := c.openOutput()
+	if err != nil {
+		return nil, err
+	}
+	l := newLogger(w, level, delimiter)
+	if closer, ok := w.(io.Closer); ok && w != os.Stdout && w != os.Stderr {
+		l.owned = append(l.owned, closer)
+	}
+	l.SetFormat(c.Format)
+	l.SetTimeFormat(timeFormat)
+	l.SetColor(c.Color)
+	l.SetSecretPolicy(c.Secrets)
+	return l, nil
+}
+
+// FromEnv builds a Logger from the environment variables with the given prefix, e.g. FromEnv("LOG") reads
+// LOG_LEVEL, LOG_FORMAT, LOG_OUTPUT, LOG_TIME_FORMAT and the other variables listed at LoadEnv.
+// Settings whose variable is not set keep the defaults of Config.
+func FromEnv(prefix string) (*Logger, error) {
+	c := new(Config)
+	if err := c.LoadEnv(prefix); err != nil {
+		return nil, err
+	}
+	return c.Build()
+}
+
+// LoadEnv sets the Config's fields from the environment variables prefix_LEVEL, prefix_FORMAT, prefix_OUTPUT,
+// prefix_DELIMITER, prefix_TIME_FORMAT, prefix_COLOR, prefix_SECRETS, prefix_ROTATE_MAX_SIZE, prefix_ROTATE_INTERVAL,
+// prefix_ROTATE_MAX_BACKUPS and prefix_ROTATE_COMPRESS. Fields whose variable is not set are left unchanged.
+// If prefix is empty, the variables' names have no prefix, e.g. LEVEL.
+func (c *Config) LoadEnv(prefix string) error {
+	if len(prefix) > 0 {
+		prefix += "_"
+	}
+	for _, v := range []struct {
+		name string
+		set  func(value string) error
+	}{
+		{"LEVEL", func(s string) error { return c.Level.UnmarshalText([]byte(s)) }},
+		{"FORMAT", func(s string) error { return c.Format.UnmarshalText([]byte(s)) }},
+		{"OUTPUT", func(s string) error { c.Output = s; return nil }},
+		{"DELIMITER", func(s string) error { c.Delimiter = s; return nil }},
+		{"TIME_FORMAT", func(s string) error { c.TimeFormat = s; return nil }},
+		{"COLOR", func(s string) error { return c.Color.UnmarshalText([]byte(s)) }},
+		{"SECRETS", func(s string) error { return c.Secrets.UnmarshalText([]byte(s)) }},
+		{"ROTATE_MAX_SIZE", func(s string) (err error) { c.rotation().MaxSize, err = strconv.ParseInt(s, 10, 64); return }},
+		{"ROTATE_INTERVAL", func(s string) error { c.rotation().Interval = s; return nil }},
+		{"ROTATE_MAX_BACKUPS", func(s string) (err error) { c.rotation().MaxBackups, err = strconv.Atoi(s); return }},
+		{"ROTATE_COMPRESS", func(s string) (err error) { c.rotation().Compress, err = strconv.ParseBool(s); return }},
+	} {
+		value, ok := os.LookupEnv(prefix + v.name)
+		if !ok {
+			continue
+		}
+		if err := v.set(value); err != nil {
+			return fmt.Errorf("Environment variable %s: %w", prefix+v.name, err)
+		}
+	}
+	return nil
+}
+
+// openOutput opens the writer described by c.Output and c.Rotation.
+func (c *Config) openOutput() (io.Writer, error) {
+	var std io.Writer
+	switch c.Output {
+	case "", "stderr":
+		std = os.Stderr
+	case "stdout":
+		std = os.Stdout
+	}
+	if std != nil {
+		if c.Rotation != nil {
+			return nil, errors.New("Rotation requires a file as output")
+		}
+		return std, nil
+	}
+	if c.Rotation == nil {
+		return os.OpenFile(c.Output, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	}
+	var interval time.Duration
+	if len(c.Rotation.Interval) > 0 {
+		var err error
+		if interval, err = time.ParseDuration(c.Rotation.Interval); err != nil {
+			return nil, err
+		}
+	}
+	return NewRotatingFile(c.Output, RotateOptions{
+		MaxSize:    c.Rotation.MaxSize,
+		Interval:   interval,
+		MaxBackups: c.Rotation.MaxBackups,
+		Compress:   c.Rotation.Compress,
+	})
+}
+
+// rotation returns c.Rotation, which is allocated if it is nil.
+func (c *Config) rotation() *RotationConfig {
+	if c.Rotation == nil {
+		c.Rotation = new(RotationConfig)
+	}
+	return c.Rotation
+}
@@ -0,0 +1,103 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	doc := `{"level":"debug","format":"json","output":` + strconv.Quote(path) +
+		`,"time_format":"RFC3339","color":"never","rotation":{"max_size":1048576,"interval":"24h","max_backups":3}}`
+	var c Config
+	if err := json.Unmarshal([]byte(doc), &c); err != nil {
+		t.Fatal(err)
+	}
+	l, err := c.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if l.Level() != LevelDebug || l.Format() != FormatJSON || l.TimeFormat() != time.RFC3339 {
+		t.Errorf("Unexpected settings: level %s, format %s, time format %q", l.Level(), l.Format(), l.TimeFormat())
+	}
+	if _, ok := l.out.(*RotatingFile); !ok {
+		t.Errorf("Expected a *RotatingFile as output. Got %T", l.out)
+	}
+	l.Debug("configured")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"message":"configured"`) {
+		t.Errorf("Unexpected file content %q", b)
+	}
+}
+
+func TestConfigInvalid(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"format":"xml"}`), &c); err == nil {
+		t.Error("Expected an error for an undefined format")
+	}
+	c = Config{Rotation: &RotationConfig{MaxSize: 1}}
+	if _, err := c.Build(); err == nil {
+		t.Error("Expected an error for rotating stderr")
+	}
+	c = Config{Level: LevelTrace + 1}
+	if _, err := c.Build(); err == nil {
+		t.Error("Expected an error for an undefined loglevel")
+	}
+}
+
+func TestConfigLoadEnv(t *testing.T) {
+	t.Setenv("APP_LOG_LEVEL", "warning")
+	t.Setenv("APP_LOG_OUTPUT", "stdout")
+	t.Setenv("APP_LOG_ROTATE_MAX_BACKUPS", "5")
+	c := Config{Format: FormatJSON}
+	if err := c.LoadEnv("APP_LOG"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Level != LevelWarning || c.Output != "stdout" || c.Format != FormatJSON || c.Rotation == nil || c.Rotation.MaxBackups != 5 {
+		t.Errorf("Unexpected config %+v", c)
+	}
+	t.Setenv("APP_LOG_COLOR", "sometimes")
+	if err := c.LoadEnv("APP_LOG"); err == nil || !strings.Contains(err.Error(), "APP_LOG_COLOR") {
+		t.Errorf("Expected an error naming APP_LOG_COLOR. Got %v", err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("LOG_FORMAT", "json")
+	t.Setenv("LOG_OUTPUT", path)
+	t.Setenv("LOG_TIME_FORMAT", "RFC3339")
+	l, err := FromEnv("LOG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if l.Level() != LevelDebug || l.TimeFormat() != time.RFC3339 {
+		t.Errorf("Unexpected level %s or time format %q", l.Level(), l.TimeFormat())
+	}
+	l.Debug("from env")
+	l.Close()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"message":"from env"`) {
+		t.Errorf("Expected a JSON record. Got %q", b)
+	}
+	t.Setenv("LOG_LEVEL", "loud")
+	if _, err := FromEnv("LOG"); err == nil {
+		t.Error("Expected an error for an invalid loglevel")
+	}
+}
@@ -0,0 +1,12 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build !unix
+
+package logger
+
+import "net"
+
+// connAlive always returns true as closed connections can only be detected on unix systems.
+func connAlive(conn net.Conn) bool {
+	return true
+}
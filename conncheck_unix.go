@@ -0,0 +1,36 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build unix
+
+package logger
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// connAlive returns false if the remote end has closed the stream connection conn. As collectors do not
+// send anything, readable data or EOF indicates a closed connection. Writing to such a connection would
+// succeed at first and lose the record. The check peeks at the socket without blocking.
+func connAlive(conn net.Conn) bool {
+	if _, ok := conn.(net.PacketConn); ok {
+		return true
+	}
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return true
+	}
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		return false
+	}
+	alive := true
+	err = rc.Read(func(fd uintptr) bool {
+		var buf [1]byte
+		_, _, err := syscall.Recvfrom(int(fd), buf[:], syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		alive = errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EWOULDBLOCK)
+		return true
+	})
+	return err == nil && alive
+}
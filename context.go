@@ -0,0 +1,124 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"context"
+)
+
+// contextKey is the type of the keys this package uses to store values in a context.Context.
+type contextKey int
+
+const (
+	loggerKey contextKey = iota // Key of the Logger stored in a context.
+	fieldsKey                   // Key of the fields stored in a context.
+)
+
+// ContextExtractor returns fields from a context that will be attached to records logged with that context.
+type ContextExtractor func(ctx context.Context) []Field
+
+// NewContext returns a copy of ctx that carries l.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	if l == nil {
+		panic("Programming error: logger.NewContext: Passed nil as Logger")
+	}
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext returns the Logger stored in ctx. If ctx does not carry a Logger, the default logger is returned.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerKey).(*Logger); ok {
+		return l
+	}
+	return Default()
+}
+
+// ContextWithFields returns a copy of ctx that carries the key/value pairs kv in addition to the fields already
+// stored in ctx. The fields are attached to all records that are logged with the context, e.g. by InfoCtx.
+func ContextWithFields(ctx context.Context, kv ...any) context.Context {
+	parent := contextFields(ctx)
+	fields := make([]Field, len(parent), len(parent)+(len(kv)+1)/2)
+	copy(fields, parent)
+	return context.WithValue(ctx, fieldsKey, append(fields, fieldsFromKV(kv)...))
+}
+
+// contextFields returns the fields stored in ctx.
+func contextFields(ctx context.Context) []Field {
+	fields, _ := ctx.Value(fieldsKey).([]Field)
+	return fields
+}
+
+// AlertCtx sends a message of loglevel LevelAlert with the fields of ctx attached to the Logger.
+func (l *Logger) AlertCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelAlert, v...)
+}
+
+// CriticalCtx sends a message of loglevel LevelCritical with the fields of ctx attached to the Logger.
+func (l *Logger) CriticalCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelCritical, v...)
+}
+
+// DebugCtx sends a message of loglevel LevelDebug with the fields of ctx attached to the Logger.
+func (l *Logger) DebugCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelDebug, v...)
+}
+
+// ErrorCtx sends a message of loglevel LevelError with the fields of ctx attached to the Logger.
+func (l *Logger) ErrorCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelError, v...)
+}
+
+// InfoCtx sends a message of loglevel LevelInfo with the fields of ctx attached to the Logger.
+func (l *Logger) InfoCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelInfo, v...)
+}
+
+// NoticeCtx sends a message of loglevel LevelNotice with the fields of ctx attached to the Logger.
+func (l *Logger) NoticeCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelNotice, v...)
+}
+
+// PanicCtx sends a message of loglevel LevelPanic with the fields of ctx attached to the Logger.
+// Please note that it does NOT call panic()!
+func (l *Logger) PanicCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelPanic, v...)
+}
+
+// PrintCtx writes the log message if its log level is equally severe or more severe than that set for the Logger.
+// The record carries the fields stored in ctx by ContextWithFields, followed by the fields returned by the
+// Logger's context extractor.
+func (l *Logger) PrintCtx(ctx context.Context, level Level, v ...any) (n int, err error) {
+	if !l.Enabled(level) {
+		return 0, nil
+	}
+	return l.Output(Record{Level: level, Message: sprint(resolveLazy(v)), Fields: contextFields(ctx), ctx: ctx})
+}
+
+// SetContextExtractor sets a function that pulls fields like a trace ID out of the context passed to
+// the Logger's context-aware methods. Passing nil removes the extractor. The extractor is called while
+// the Logger is locked, except by a sharded Logger, see NewSharded.
+func (l *Logger) SetContextExtractor(extract ContextExtractor) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.extractor = extract
+	l.snapshotShards()
+}
+
+// TraceCtx sends a message of loglevel LevelTrace with the fields of ctx attached to the Logger.
+func (l *Logger) TraceCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelTrace, v...)
+}
+
+// WarningCtx sends a message of loglevel LevelWarning with the fields of ctx attached to the Logger.
+func (l *Logger) WarningCtx(ctx context.Context, v ...any) (n int, err error) {
+	return l.PrintCtx(ctx, LevelWarning, v...)
+}
+
+// extract appends the fields the Logger's context extractor returns for the context of rec, see PrintCtx.
+// The caller must hold the Logger's lock or its read lock.
+func (l *Logger) extract(rec *Record) {
+	if rec.ctx != nil && l.extractor != nil {
+		rec.Fields = append(rec.Fields[:len(rec.Fields):len(rec.Fields)], l.extractor(rec.ctx)...)
+	}
+	rec.ctx = nil
+}
@@ -0,0 +1,37 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type traceKey struct{}
+
+func TestContext(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetContextExtractor(func(ctx context.Context) []Field {
+		if id, ok := ctx.Value(traceKey{}).(string); ok {
+			return []Field{{Key: "trace", Value: id}}
+		}
+		return nil
+	})
+	ctx := NewContext(context.Background(), l)
+	ctx = ContextWithFields(ctx, "request", 42)
+	ctx = context.WithValue(ctx, traceKey{}, "abc")
+	if FromContext(ctx) != l {
+		t.Fatal("FromContext did not return the Logger stored by NewContext")
+	}
+	FromContext(ctx).DebugCtx(ctx, "invisible")
+	FromContext(ctx).InfoCtx(ctx, "handled")
+	expect := "[Info] - handled - request=42 trace=abc\n"
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+	if fields := contextFields(ctx); len(fields) != 1 {
+		t.Errorf("Context extractor modified the context's fields: %v", fields)
+	}
+}
@@ -0,0 +1,92 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// crashExitCode is the exit code of a program terminated by HandleCrash, the same as for an unrecovered panic.
+const crashExitCode = 2
+
+// Registers HandleCrash as a function that is skipped when determining the caller of a record.
+func init() {
+	skipFunctions(HandleCrash)
+}
+
+// CrashFile returns the path HandleCrash writes crash reports to, an empty string means os.Stderr.
+func (l *Logger) CrashFile() string {
+	defer l.runlock(l.rlock())
+	return l.crashFile
+}
+
+// SetCrashFile sets the path HandleCrash writes crash reports to. An existing file is replaced by the report.
+// Passing an empty string writes crash reports to os.Stderr, which is the default.
+func (l *Logger) SetCrashFile(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.crashFile = path
+}
+
+// HandleCrash is the last resort for a panic nobody recovered from. It must be called directly by a defer statement,
+// usually as the first statement of main:
+//
+//	defer logger.HandleCrash(l)
+//
+// If the surrounding function panics, HandleCrash writes a crash report with the panic value, the stack trace of
+// the panicking goroutine and the records kept by the Logger's flight recorder, see SetFlightRecorder, to the crash
+// file, see SetCrashFile. Then it sends the panic with loglevel LevelPanic to l, flushes l, runs the exit hooks and
+// terminates the program with exit code 2 like an unrecovered panic. Panics of other goroutines are not handled.
+// An exit requested in deferred exit mode, see SetDeferredExit, is carried out like Main does.
+func HandleCrash(l *Logger) {
+	if v := recover(); v != nil {
+		if r, ok := v.(*exitRequest); ok {
+			r.l.terminate(r.code)
+			return
+		}
+		l.crash(v)
+	}
+}
+
+// crash implements HandleCrash for the panic value v.
+func (l *Logger) crash(v any) {
+	l.lock()
+	stack := l.stacktrace()
+	report := l.crashReport(v, stack)
+	path := l.crashFile
+	l.unlock()
+	if err := writeCrashReport(path, report); err != nil {
+		l.lock()
+		l.handleError(err)
+		l.unlock()
+	}
+	l.Output(Record{Level: LevelPanic, Message: fmt.Sprintf("panic: %v", v), Stack: stack})
+	l.exit(crashExitCode)
+}
+
+// crashReport renders the crash report of the panic value v and its stack trace. The caller must hold the Logger's lock.
+func (l *Logger) crashReport(v any, stack string) []byte {
+	b := fmt.Appendf(nil, "panic: %v\ntime: %s\n\n%s\n", v, l.now().Format(time.RFC3339Nano), stack)
+	if l.recorder == nil || len(l.recorder.recs) < 1 {
+		return b
+	}
+	b = append(b, "\nrecent records:\n"...)
+	for _, rec := range l.recorder.records() {
+		var err error
+		if b, err = l.encodePlain(b, &rec, l.format); err != nil {
+			b = fmt.Appendf(b, "%s\n", rec.Message)
+		}
+	}
+	return b
+}
+
+// writeCrashReport writes report to the file at path, to os.Stderr if path is empty.
+func writeCrashReport(path string, report []byte) error {
+	if len(path) < 1 {
+		_, err := os.Stderr.Write(report)
+		return err
+	}
+	return os.WriteFile(path, report, 0644)
+}
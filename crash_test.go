@@ -0,0 +1,36 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHandleCrash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash.log")
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetCrashFile(path)
+	l.SetFlightRecorder(4, LevelError)
+	code := -1
+	l.SetExitFunc(func(c int) { code = c })
+	func() {
+		defer HandleCrash(l)
+		l.Debug("context")
+		panic("boom")
+	}()
+	if code != crashExitCode {
+		t.Errorf("Expected exit code %d. Got %d", crashExitCode, code)
+	}
+	report := readFile(t, path)
+	for _, s := range []string{"panic: boom\n", "TestHandleCrash", "recent records:\n[Debug] - context\n"} {
+		if !strings.Contains(report, s) {
+			t.Errorf("Expected %q in crash report %q", s, report)
+		}
+	}
+	if !strings.HasPrefix(b.String(), "[Debug] - context\n[Panic] - panic: boom\n") {
+		t.Errorf("Unexpected output %q", b.String())
+	}
+}
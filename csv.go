@@ -0,0 +1,61 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bytes"
+	"time"
+)
+
+// encodeCSV appends rec rendered in FormatCSV to b. The columns are time, level, prefix, message and fields,
+// a stack trace follows the message within the same column. The timestamp is rendered according to the Logger's
+// time style, if the style uses the time format and none is set, time.RFC3339Nano is used.
+// The caller must hold the Logger's lock.
+func (l *Logger) encodeCSV(b []byte, rec *Record) []byte {
+	col := getBuffer()
+	defer putBuffer(col)
+	*col, _ = l.appendTime((*col)[:0], rec.Time, time.RFC3339Nano)
+	b = appendCSVField(b, *col)
+	b = append(b, ',')
+	b = appendCSVField(b, []byte(rec.Level.String()))
+	b = append(b, ',')
+	b = appendCSVField(b, []byte(rec.Prefix))
+	b = append(b, ',')
+	*col = append((*col)[:0], rec.Message...)
+	if len(rec.Stack) > 0 {
+		*col = append(*col, '\n')
+		*col = append(*col, rec.Stack...)
+	}
+	b = appendCSVField(b, *col)
+	b = append(b, ',')
+	*col, _ = l.appendSegment((*col)[:0], SegmentFields, rec)
+	b = appendCSVField(b, *col)
+	return append(b, '\n')
+}
+
+// appendCSVField appends field to b as described in RFC 4180. The field is enclosed in double quotes if it contains
+// a comma, a double quote, a line break or leading or trailing spaces, double quotes within the field are doubled.
+func appendCSVField(b, field []byte) []byte {
+	if !needsCSVQuote(field) {
+		return append(b, field...)
+	}
+	b = append(b, '"')
+	for _, c := range field {
+		if c == '"' {
+			b = append(b, '"')
+		}
+		b = append(b, c)
+	}
+	return append(b, '"')
+}
+
+// needsCSVQuote returns true if field must be enclosed in double quotes.
+func needsCSVQuote(field []byte) bool {
+	if len(field) < 1 {
+		return false
+	}
+	if field[0] == ' ' || field[len(field)-1] == ' ' {
+		return true
+	}
+	return bytes.ContainsAny(field, ",\"\r\n")
+}
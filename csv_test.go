@@ -0,0 +1,39 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatCSV(t *testing.T) {
+	out := new(strings.Builder)
+	l := New(out, LevelInfo, loglevelDelimiter)
+	l.SetFormat(FormatCSV)
+	l.SetClock(func() time.Time { return time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC) })
+	l.Info("plain")
+	l.WithPrefix("db").InfoKV(`said "hi", then left`, "user", "bob", "id", 7)
+	l.Warning("two\nlines")
+	expected := [][]string{
+		{"2023-05-01T12:00:00Z", "Info", "", "plain", ""},
+		{"2023-05-01T12:00:00Z", "Info", "db", `said "hi", then left`, "user=bob id=7"},
+		{"2023-05-01T12:00:00Z", "Warning", "", "two\nlines", ""},
+	}
+	got, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q. Got %q", expected, got)
+	}
+}
+
+func TestParseFormatCSV(t *testing.T) {
+	if f, err := ParseFormat("csv"); err != nil || f != FormatCSV {
+		t.Errorf("Expected %s. Got %s, %v", FormatCSV, f, err)
+	}
+}
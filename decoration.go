@@ -0,0 +1,46 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// EmojiLevelDecorations returns level decorations for programs aimed at end users, see SetLevelDecorations.
+func EmojiLevelDecorations() map[Level]string {
+	return map[Level]string{
+		LevelPanic:    "💥",
+		LevelAlert:    "🚨",
+		LevelCritical: "🔥",
+		LevelError:    "❌",
+		LevelWarning:  "⚠️",
+		LevelNotice:   "📌",
+		LevelInfo:     "ℹ️",
+		LevelDebug:    "🐛",
+		LevelTrace:    "🔍",
+		LevelAudit:    "📝",
+	}
+}
+
+// LevelDecorations returns a copy of the Logger's level decorations.
+func (l *Logger) LevelDecorations() map[Level]string {
+	defer l.runlock(l.rlock())
+	decorations := make(map[Level]string, len(l.decorations))
+	for lvl, d := range l.decorations {
+		decorations[lvl] = d
+	}
+	return decorations
+}
+
+// SetLevelDecorations sets prefixes, e.g. glyphs like "❌" for LevelError, that precede the level tags of the
+// given loglevels in FormatText, separated by a space. Loglevels without decoration are rendered as usual.
+// Passing nil or an empty map removes all decorations. Passing an invalid loglevel will cause a panic.
+func (l *Logger) SetLevelDecorations(decorations map[Level]string) {
+	copied := make(map[Level]string, len(decorations))
+	for lvl, d := range decorations {
+		assertLoglevel(lvl)
+		copied[lvl] = d
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(copied) < 1 {
+		copied = nil
+	}
+	l.decorations = copied
+}
@@ -0,0 +1,28 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLevelDecorations(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	decorations := EmojiLevelDecorations()
+	delete(decorations, LevelInfo)
+	l.SetLevelDecorations(decorations)
+	decorations[LevelError] = "changed"
+	l.Error("failed")
+	l.Info("plain")
+	l.SetLevelDecorations(nil)
+	l.Warning("undecorated")
+	expected := "❌ [Error] - failed\n[Info] - plain\n[Warning] - undecorated\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	if len(l.LevelDecorations()) != 0 {
+		t.Errorf("Expected no decorations. Got %v", l.LevelDecorations())
+	}
+}
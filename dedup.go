@@ -0,0 +1,120 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+// dedup holds the state of the duplicate-message suppression of a Logger.
+type dedup struct {
+	window     time.Duration
+	last       Record // Last record that was written.
+	repeated   int    // Number of suppressed repetitions of last.
+	generation uint64 // Incremented every time the repetitions are reported, invalidates pending timers.
+	timer      *time.Timer
+}
+
+// DedupWindow returns the window of the Logger's duplicate-message suppression, 0 means it is disabled.
+func (l *Logger) DedupWindow() time.Duration {
+	defer l.runlock(l.rlock())
+	if l.dedup == nil {
+		return 0
+	}
+	return l.dedup.window
+}
+
+// SetDedupWindow enables the suppression of identical consecutive records, like syslogd does.
+// Records are identical if their level, prefix, message and fields match. The first record is written,
+// repetitions are counted instead. The count is written as record "last message repeated N times" once
+// a different record arrives or window has passed since the first suppressed repetition.
+// Passing 0 disables the suppression, a pending count is written immediately. A negative window will cause a panic.
+func (l *Logger) SetDedupWindow(window time.Duration) {
+	if window < 0 {
+		panic("Programming error: (l *Logger) SetDedupWindow(): Passed negative window")
+	}
+	l.mu.Lock()
+	var summary *Record
+	if l.dedup != nil {
+		summary = l.repeatSummary()
+	}
+	if window == 0 {
+		l.dedup = nil
+	} else if l.dedup == nil {
+		l.dedup = &dedup{window: window}
+	} else {
+		l.dedup.window = window
+	}
+	l.mu.Unlock()
+	l.emit(summary)
+}
+
+// deduplicate returns true if rec repeats the last record and has to be suppressed. If rec differs from
+// the last record and repetitions have been suppressed, a record reporting them is returned as summary.
+// The caller must hold the Logger's lock.
+func (l *Logger) deduplicate(rec *Record) (summary *Record, suppress bool) {
+	d := l.dedup
+	if d.last.Level == rec.Level && d.last.Prefix == rec.Prefix && d.last.Message == rec.Message &&
+		textFields(d.last.Fields) == textFields(rec.Fields) {
+		if d.repeated == 0 {
+			generation := d.generation
+			d.timer = time.AfterFunc(d.window, func() { l.reportRepeated(generation) })
+		}
+		d.repeated++
+		return nil, true
+	}
+	summary = l.repeatSummary()
+	d.last = *rec
+	return summary, false
+}
+
+// repeatSummary returns a record that reports the suppressed repetitions and resets their count.
+// It returns nil if there are none. The caller must hold the Logger's lock.
+func (l *Logger) repeatSummary() *Record {
+	d := l.dedup
+	if d.repeated == 0 {
+		return nil
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	summary := &Record{
+		Level:   d.last.Level,
+		Time:    l.now(),
+		Prefix:  d.last.Prefix,
+		Message: fmt.Sprintf("last message repeated %d times", d.repeated),
+	}
+	d.repeated = 0
+	d.generation++
+	return summary
+}
+
+// reportRepeated writes the suppressed repetitions once the window has passed. generation identifies
+// the repetitions the timer was started for, if they have been reported already, nothing is written.
+func (l *Logger) reportRepeated(generation uint64) {
+	l.mu.Lock()
+	if l.dedup == nil || l.dedup.generation != generation {
+		l.mu.Unlock()
+		return
+	}
+	summary := l.repeatSummary()
+	l.mu.Unlock()
+	l.emit(summary)
+}
+
+// emit writes or queues rec bypassing filters and hooks. Nothing happens if rec is nil.
+// The caller must not hold the Logger's lock.
+func (l *Logger) emit(rec *Record) {
+	if rec == nil {
+		return
+	}
+	if l.async != nil {
+		l.enqueue(asyncItem{rec: rec})
+		return
+	}
+	l.lock()
+	defer l.unlock()
+	l.write(rec)
+}
@@ -0,0 +1,63 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuilder is a strings.Builder that can be used by multiple goroutines.
+type syncBuilder struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (s *syncBuilder) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuilder) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+func TestDedup(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetDedupWindow(time.Hour)
+	for i := 0; i < 4; i++ {
+		l.Error("connection refused")
+	}
+	l.Info("connected")
+	l.Info("connected")
+	l.SetDedupWindow(0)
+	expect := "[Error] - connection refused\n" +
+		"[Error] - last message repeated 3 times\n" +
+		"[Info] - connected\n" +
+		"[Info] - last message repeated 1 times\n"
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
+
+func TestDedupWindow(t *testing.T) {
+	b := new(syncBuilder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetDedupWindow(10 * time.Millisecond)
+	l.Error("timeout")
+	l.Error("timeout")
+	expect := "[Error] - timeout\n[Error] - last message repeated 1 times\n"
+	deadline := time.Now().Add(5 * time.Second)
+	for b.String() != expect && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
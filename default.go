@@ -2,20 +2,168 @@
 
 package logger
 
-import "io"
+import (
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// Delimiter of the default logger if it is created implicitly by Default().
+const defaultDelimiter = " - "
 
 // Stores the default logger
-var defaultLogger *Logger
+var defaultLogger atomic.Pointer[Logger]
+
+// Registers the package-level print functions as functions that are skipped when determining the caller of a record.
+func init() {
+	skipFunctions(
+		Alert, Alertf, Critical, Criticalf, Debug, Debugf, Die, DieCode, Dief, DiefCode, Error, Errorf,
+		Info, Infof, Notice, Noticef, Panic, Panicf, Println, Printf, Trace, Tracef, Warning, Warningf,
+	)
+}
 
-// Returns the default logger, will panic if SetupDefaultLogger() has not been called yet.
+// Returns the default logger. If no default logger has been set, a default logger writing records of
+// LevelInfo and more severe ones to os.Stderr is created. Default can be used by multiple goroutines.
 func Default() *Logger {
-	if defaultLogger == nil {
-		panic("Programming error: Default logger is not set, please set it up first by calling SetupDefaultLogger()")
+	if l := defaultLogger.Load(); l != nil {
+		return l
 	}
-	return defaultLogger
+	defaultLogger.CompareAndSwap(nil, New(os.Stderr, LevelInfo, defaultDelimiter))
+	return defaultLogger.Load()
+}
+
+// Sets l as the default logger that is returned by Default() and used by the package-level print functions.
+// SetDefault can be used by multiple goroutines.
+func SetDefault(l *Logger) {
+	if l == nil {
+		panic("Programming error: logger.SetDefault: Passed nil as Logger")
+	}
+	defaultLogger.Store(l)
 }
 
 // Creates a default logger that can be used by calling Default().
 func SetupDefaultLogger(w io.Writer, level Level, delimiter string) {
-	defaultLogger = New(w, level, delimiter)
+	SetDefault(New(w, level, delimiter))
+}
+
+// Alert sends a message of loglevel LevelAlert to the default logger.
+func Alert(v ...any) (n int, err error) {
+	return Default().Alert(v...)
+}
+
+// Alertf sends a formatted message of loglevel LevelAlert to the default logger.
+func Alertf(format string, a ...any) (n int, err error) {
+	return Default().Alertf(format, a...)
+}
+
+// Critical sends a message of loglevel LevelCritical to the default logger.
+func Critical(v ...any) (n int, err error) {
+	return Default().Critical(v...)
+}
+
+// Criticalf sends a formatted message of loglevel LevelCritical to the default logger.
+func Criticalf(format string, a ...any) (n int, err error) {
+	return Default().Criticalf(format, a...)
+}
+
+// Debug sends a message of loglevel LevelDebug to the default logger.
+func Debug(v ...any) (n int, err error) {
+	return Default().Debug(v...)
+}
+
+// Debugf sends a formatted message of loglevel LevelDebug to the default logger.
+func Debugf(format string, a ...any) (n int, err error) {
+	return Default().Debugf(format, a...)
+}
+
+// Die sends a message of loglevel LevelPanic to the default logger, runs its exit hooks, then exits with its exit code.
+func Die(v ...any) {
+	Default().Die(v...)
+}
+
+// Dief sends a formatted message of loglevel LevelPanic to the default logger, runs its exit hooks, then exits with its exit code.
+func Dief(format string, a ...any) {
+	Default().Dief(format, a...)
+}
+
+// DieCode sends a message of loglevel LevelPanic to the default logger, runs its exit hooks, then exits with code.
+func DieCode(code int, v ...any) {
+	Default().DieCode(code, v...)
+}
+
+// DiefCode sends a formatted message of loglevel LevelPanic to the default logger, runs its exit hooks, then exits with code.
+func DiefCode(code int, format string, a ...any) {
+	Default().DiefCode(code, format, a...)
+}
+
+// Error sends a message of loglevel LevelError to the default logger.
+func Error(v ...any) (n int, err error) {
+	return Default().Error(v...)
+}
+
+// Errorf sends a formatted message of loglevel LevelError to the default logger.
+func Errorf(format string, a ...any) (n int, err error) {
+	return Default().Errorf(format, a...)
+}
+
+// Info sends a message of loglevel LevelInfo to the default logger.
+func Info(v ...any) (n int, err error) {
+	return Default().Info(v...)
+}
+
+// Infof sends a formatted message of loglevel LevelInfo to the default logger.
+func Infof(format string, a ...any) (n int, err error) {
+	return Default().Infof(format, a...)
+}
+
+// Notice sends a message of loglevel LevelNotice to the default logger.
+func Notice(v ...any) (n int, err error) {
+	return Default().Notice(v...)
+}
+
+// Noticef sends a formatted message of loglevel LevelNotice to the default logger.
+func Noticef(format string, a ...any) (n int, err error) {
+	return Default().Noticef(format, a...)
+}
+
+// Panic sends a message of loglevel LevelPanic to the default logger.
+// Please note that it does NOT call panic()!
+func Panic(v ...any) (n int, err error) {
+	return Default().Panic(v...)
+}
+
+// Panicf sends a formatted message of loglevel LevelPanic to the default logger.
+// Please note that it does NOT call panic()!
+func Panicf(format string, a ...any) (n int, err error) {
+	return Default().Panicf(format, a...)
+}
+
+// Println writes the log message to the default logger if its log level is equally severe or more severe than that set for the default logger.
+func Println(level Level, v ...any) (n int, err error) {
+	return Default().Println(level, v...)
+}
+
+// Printf writes a formatted log message to the default logger if the default logger was configured to print the given level.
+func Printf(level Level, format string, a ...any) (n int, err error) {
+	return Default().Printf(level, format, a...)
+}
+
+// Trace sends a message of loglevel LevelTrace to the default logger.
+func Trace(v ...any) (n int, err error) {
+	return Default().Trace(v...)
+}
+
+// Tracef sends a formatted message of loglevel LevelTrace to the default logger.
+func Tracef(format string, a ...any) (n int, err error) {
+	return Default().Tracef(format, a...)
+}
+
+// Warning sends a message of loglevel LevelWarning to the default logger.
+func Warning(v ...any) (n int, err error) {
+	return Default().Warning(v...)
+}
+
+// Warningf sends a formatted message of loglevel LevelWarning to the default logger.
+func Warningf(format string, a ...any) (n int, err error) {
+	return Default().Warningf(format, a...)
 }
@@ -0,0 +1,44 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDefaultShortcuts(t *testing.T) {
+	b := new(strings.Builder)
+	SetupDefaultLogger(b, LevelInfo, loglevelDelimiter)
+	Default().SetReportCaller(true)
+	Debug("invisible")
+	line := lineOfCaller() + 1
+	Errorf("%d errors", 3)
+	expect := fmt.Sprintf("[Error] - default_test.go:%d - 3 errors\n", line)
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
+
+func TestDefaultConcurrent(t *testing.T) {
+	defer SetDefault(Default())
+	defaultLogger.Store(nil)
+	done := make(chan *Logger)
+	for i := 0; i < 8; i++ {
+		go func() {
+			done <- Default()
+		}()
+	}
+	first := <-done
+	for i := 1; i < 8; i++ {
+		if l := <-done; l != first {
+			t.Error("Default returned different fallback loggers")
+		}
+	}
+	l := New(new(strings.Builder), LevelDebug, loglevelDelimiter)
+	SetDefault(l)
+	if Default() != l {
+		t.Error("Default did not return the Logger set by SetDefault")
+	}
+}
@@ -0,0 +1,38 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"sort"
+	"time"
+)
+
+// deterministicTime is the timestamp of the records of a Logger in deterministic mode.
+var deterministicTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Deterministic returns true if the Logger is in deterministic mode, see SetDeterministic.
+func (l *Logger) Deterministic() bool {
+	return l.deterministic.Load()
+}
+
+// SetDeterministic enables or disables the deterministic mode, which makes the Logger's output reproducible
+// for golden-file tests. In deterministic mode, records are timestamped 2000-01-01T00:00:00Z, which is rendered
+// in UTC regardless of the time style, the elapsed time is always 0, fields are sorted by their keys and colors
+// are disabled. Records passed to Output with a timestamp keep it. Disabling the mode restores the previous behavior.
+func (l *Logger) SetDeterministic(enable bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.deterministic.Store(enable)
+	l.updateColorize()
+}
+
+// sortFields sorts the fields of rec by their keys, keeping the order of fields with the same key.
+// The fields are copied before they are sorted.
+func sortFields(rec *Record) {
+	if sort.SliceIsSorted(rec.Fields, func(i, j int) bool { return rec.Fields[i].Key < rec.Fields[j].Key }) {
+		return
+	}
+	fields := append([]Field(nil), rec.Fields...)
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Key < fields[j].Key })
+	rec.Fields = fields
+}
@@ -0,0 +1,29 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeterministic(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetColor(ColorAlways)
+	l.SetTimeFormat(time.RFC3339)
+	l.SetDeterministic(true)
+	l.WithFields(map[string]any{"service": "api"}).InfoKV("request", "status", 200, "method", "GET", "method", "HEAD")
+	l.SetFormat(FormatJSON)
+	l.WarningKV("slow", "ms", 1200, "db", "users")
+	expected := "[Info] - 2000-01-01T00:00:00Z - request - method=GET method=HEAD service=api status=200\n" +
+		`{"level":"Warning","time":"2000-01-01T00:00:00Z","message":"slow","fields":{"db":"users","ms":1200}}` + "\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	l.SetDeterministic(false)
+	if !l.colorize || l.Deterministic() {
+		t.Error("Disabling the deterministic mode did not restore the colors")
+	}
+}
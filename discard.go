@@ -0,0 +1,20 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import "io"
+
+// Discard returns a Logger that drops all records without formatting them or acquiring its lock.
+// Libraries can use it as default for an optional *Logger instead of checking for nil. The returned
+// Logger keeps discarding records regardless of its settings, only Die and Dief still exit the program
+// and PanicNow and PanicNowf still panic.
+func Discard() *Logger {
+	l := newLogger(io.Discard, LevelPanic, defaultDelimiter)
+	l.discard = true
+	return l
+}
+
+// IsDiscard returns true if the Logger has been created by Discard.
+func (l *Logger) IsDiscard() bool {
+	return l.discard
+}
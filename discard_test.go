@@ -0,0 +1,44 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type stringerFunc func() string
+
+func (f stringerFunc) String() string {
+	return f()
+}
+
+func TestDiscard(t *testing.T) {
+	l := Discard()
+	b := new(strings.Builder)
+	l.SetOutput(b)
+	l.SetLevel(LevelDebug)
+	formatted := false
+	arg := stringerFunc(func() string {
+		formatted = true
+		return "expensive"
+	})
+	l.Info(arg)
+	l.Errorf("%s", arg)
+	l.InfoKV("msg", "key", arg)
+	l.InfoCtx(context.Background(), arg)
+	l.Output(Record{Level: LevelPanic, Message: "msg"})
+	if formatted {
+		t.Error("Discard logger formatted its arguments")
+	}
+	if b.String() != "" {
+		t.Errorf("Discard logger wrote %q", b.String())
+	}
+	if !l.IsDiscard() || New(b, LevelDebug, loglevelDelimiter).IsDiscard() {
+		t.Error("IsDiscard returned a wrong result")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { l.Debug("msg") }); allocs > 1 {
+		t.Errorf("Expected at most 1 allocation, got %.0f", allocs)
+	}
+}
@@ -0,0 +1,96 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultDiskCheckInterval is the interval between two checks of the free disk space if DiskGuard.Interval is 0.
+const DefaultDiskCheckInterval = 10 * time.Second
+
+// DiskGuard configures the disk space watchdog of a Logger, see (l *Logger) SetDiskGuard.
+type DiskGuard struct {
+	Path     string        // A file or directory on the filesystem the Logger writes to, e.g. the path of a RotatingFile.
+	MinFree  uint64        // Degrade if fewer than MinFree bytes are available on the filesystem of Path, 0 disables the check.
+	MaxBytes uint64        // Degrade if the Logger wrote more than MaxBytes bytes since SetDiskGuard, 0 disables the check.
+	Interval time.Duration // Interval between two checks of the free disk space, 0 means DefaultDiskCheckInterval.
+}
+
+// diskGuard holds the settings and the state of the disk space watchdog.
+type diskGuard struct {
+	DiskGuard
+	base     uint64    // Bytes written by the Logger when the watchdog was set.
+	checked  time.Time // Time of the last check of the free disk space.
+	lowSpace bool
+	degraded bool
+}
+
+// SetDiskGuard protects the filesystem the Logger writes to from running full. If the free space on the filesystem
+// of g.Path falls below g.MinFree or the Logger wrote more than g.MaxBytes, the Logger switches to a degraded mode:
+// a record of LevelCritical reports the condition once, afterwards records less severe than LevelNotice are discarded.
+// If enough space becomes available again, a record of LevelNotice is written and the Logger leaves the degraded mode.
+// Passing a DiskGuard with neither MinFree nor MaxBytes disables the watchdog. An error is returned if the free
+// space of g.Path cannot be determined or g.Interval is negative.
+func (l *Logger) SetDiskGuard(g DiskGuard) error {
+	if g.Interval < 0 {
+		return errors.New("Disk check interval must not be negative")
+	}
+	if g.MinFree > 0 {
+		if _, err := freeSpace(g.Path); err != nil {
+			return err
+		}
+	}
+	if g.Interval == 0 {
+		g.Interval = DefaultDiskCheckInterval
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if g.MinFree == 0 && g.MaxBytes == 0 {
+		l.diskGuard = nil
+		return nil
+	}
+	l.diskGuard = &diskGuard{DiskGuard: g, base: l.bytesWritten.Load()}
+	return nil
+}
+
+// Degraded returns true if the disk space watchdog discards records, see SetDiskGuard.
+func (l *Logger) Degraded() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.diskGuard != nil && l.diskGuard.degraded
+}
+
+// guardDisk runs the disk space watchdog and appends a record that reports a change of the degraded mode to recs.
+// It returns true if rec has to be discarded. The caller must hold the Logger's lock.
+func (l *Logger) guardDisk(rec *Record, recs []*Record) ([]*Record, bool) {
+	g := l.diskGuard
+	if now := time.Now(); g.MinFree > 0 && now.Sub(g.checked) >= g.Interval {
+		g.checked = now
+		if free, err := freeSpace(g.Path); err != nil {
+			l.handleError(err)
+		} else {
+			g.lowSpace = free < g.MinFree
+		}
+	}
+	exceeded := g.MaxBytes > 0 && l.bytesWritten.Load()-g.base > g.MaxBytes
+	if degraded := g.lowSpace || exceeded; degraded != g.degraded {
+		g.degraded = degraded
+		report := &Record{Level: LevelNotice, Time: l.now(), Message: "Disk space recovered, records are no longer discarded"}
+		if g.lowSpace {
+			report.Level = LevelCritical
+			report.Message = fmt.Sprintf("Less than %d bytes available on the filesystem of %q, discarding records less severe than Notice", g.MinFree, g.Path)
+		} else if exceeded {
+			report.Level = LevelCritical
+			report.Message = fmt.Sprintf("Logger wrote more than %d bytes, discarding records less severe than Notice", g.MaxBytes)
+		}
+		recs = append(recs, report)
+	}
+	if g.degraded && rec.Level > LevelNotice {
+		l.dropped.Add(1)
+		return recs, true
+	}
+	return recs, false
+}
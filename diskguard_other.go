@@ -0,0 +1,12 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build !(linux || darwin || freebsd || dragonfly)
+
+package logger
+
+import "errors"
+
+// freeSpace returns an error as the free disk space can only be determined on some unix systems.
+func freeSpace(path string) (uint64, error) {
+	return 0, errors.New("Free disk space cannot be determined on this platform")
+}
@@ -0,0 +1,16 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build linux || darwin || freebsd || dragonfly
+
+package logger
+
+import "syscall"
+
+// freeSpace returns the number of bytes available to unprivileged users on the filesystem of path.
+func freeSpace(path string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
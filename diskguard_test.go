@@ -0,0 +1,55 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiskGuardMaxBytes(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	if err := l.SetDiskGuard(DiskGuard{MaxBytes: 10}); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("first")
+	l.Info("second")
+	l.Debug("third")
+	l.Warning("fourth")
+	expected := "[Info] - first\n" +
+		"[Critical] - Logger wrote more than 10 bytes, discarding records less severe than Notice\n" +
+		"[Warning] - fourth\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	if !l.Degraded() || l.Dropped() != 2 {
+		t.Errorf("Expected degraded mode and 2 dropped records. Got %t and %d", l.Degraded(), l.Dropped())
+	}
+	if err := l.SetDiskGuard(DiskGuard{}); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("fifth")
+	if l.Degraded() || !strings.HasSuffix(b.String(), "[Info] - fifth\n") {
+		t.Errorf("Disabled watchdog still discards records: %q", b.String())
+	}
+}
+
+func TestDiskGuardMinFree(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := freeSpace(dir); err != nil {
+		t.Skip(err)
+	}
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	if err := l.SetDiskGuard(DiskGuard{Path: dir, MinFree: 1 << 62}); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("discarded")
+	if !l.Degraded() || !strings.HasPrefix(b.String(), "[Critical] - Less than") || strings.Contains(b.String(), "discarded\n") {
+		t.Errorf("Unexpected output %q", b.String())
+	}
+	if err := l.SetDiskGuard(DiskGuard{Path: dir + "/missing/", MinFree: 1}); err == nil {
+		t.Error("Expected an error for a missing path")
+	}
+}
@@ -0,0 +1,162 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// maxDumpDepth is the number of nested values Dump descends into, deeper values are replaced by "...".
+const maxDumpDepth = 8
+
+// dumpIndent indents the nested values of a dump.
+const dumpIndent = "  "
+
+// Types whose values are rendered by their String or Error method in a dump.
+var (
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Dump sends label and a multi-line rendering of value to the Logger, which replaces printing values for debugging
+// with fmt or third-party packages. Structs, maps, slices and pointers are expanded with their type names up to
+// a depth of 8, map keys are sorted. Values with a String or Error method are rendered by that method unless they
+// are held by unexported fields. A pointer to a value that is being rendered is marked as cycle. value is only
+// rendered if level is enabled:
+//
+//	l.Dump(logger.LevelDebug, "config", cfg)
+func (l *Logger) Dump(level Level, label string, value any) (n int, err error) {
+	if !l.Enabled(level) {
+		return 0, nil
+	}
+	d := &dumper{b: append([]byte(label), ": "...), visited: make(map[uintptr]bool)}
+	d.dump(reflect.ValueOf(value), 0)
+	return l.Output(Record{Level: level, Message: string(d.b)})
+}
+
+// dumper renders values for Dump.
+type dumper struct {
+	b       []byte
+	visited map[uintptr]bool // Pointers and maps that are being rendered.
+}
+
+// dump appends v at the nesting level depth.
+func (d *dumper) dump(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		d.b = append(d.b, "nil"...)
+		return
+	}
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Chan, reflect.Func:
+		if v.IsNil() {
+			d.b = fmt.Appendf(d.b, "%s(nil)", t)
+			return
+		}
+	}
+	if v.CanInterface() && (t.Implements(errorType) || t.Implements(stringerType)) {
+		d.b = append(d.b, t.String()...)
+		d.b = append(d.b, '(')
+		if err, ok := v.Interface().(error); ok {
+			d.b = strconv.AppendQuote(d.b, err.Error())
+		} else {
+			d.b = strconv.AppendQuote(d.b, v.Interface().(fmt.Stringer).String())
+		}
+		d.b = append(d.b, ')')
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		if len(t.PkgPath()) > 0 {
+			d.b = fmt.Appendf(d.b, "%s(%v)", t, v)
+		} else {
+			d.b = fmt.Appendf(d.b, "%v", v)
+		}
+	case reflect.String:
+		d.b = strconv.AppendQuote(d.b, v.String())
+	case reflect.Interface:
+		d.dump(v.Elem(), depth)
+	case reflect.Pointer:
+		if d.visited[v.Pointer()] {
+			d.b = fmt.Appendf(d.b, "<cycle %s>", t)
+			return
+		}
+		d.visited[v.Pointer()] = true
+		d.b = append(d.b, '&')
+		d.dump(v.Elem(), depth)
+		delete(d.visited, v.Pointer())
+	case reflect.Struct:
+		d.composite(t, v.NumField(), depth, func(i int) {
+			d.b = append(d.b, t.Field(i).Name...)
+			d.b = append(d.b, ": "...)
+			d.dump(v.Field(i), depth+1)
+		})
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && v.Kind() == reflect.Slice {
+			d.b = fmt.Appendf(d.b, "%s(%q)", t, v.Bytes())
+			return
+		}
+		d.composite(t, v.Len(), depth, func(i int) {
+			d.dump(v.Index(i), depth+1)
+		})
+	case reflect.Map:
+		if d.visited[v.Pointer()] {
+			d.b = fmt.Appendf(d.b, "<cycle %s>", t)
+			return
+		}
+		d.visited[v.Pointer()] = true
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			kd := &dumper{visited: d.visited}
+			kd.dump(k, maxDumpDepth)
+			names[i] = string(kd.b)
+		}
+		order := make([]int, len(keys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return names[order[i]] < names[order[j]] })
+		d.composite(t, len(keys), depth, func(i int) {
+			d.b = append(d.b, names[order[i]]...)
+			d.b = append(d.b, ": "...)
+			d.dump(v.MapIndex(keys[order[i]]), depth+1)
+		})
+		delete(d.visited, v.Pointer())
+	default:
+		d.b = fmt.Appendf(d.b, "%s(%#x)", t, v.Pointer())
+	}
+}
+
+// composite appends a struct, slice, array or map of type t with n elements. Each element is appended by elem
+// on its own line, indented one level deeper than depth.
+func (d *dumper) composite(t reflect.Type, n, depth int, elem func(i int)) {
+	d.b = append(d.b, t.String()...)
+	if n < 1 {
+		d.b = append(d.b, "{}"...)
+		return
+	}
+	if depth >= maxDumpDepth {
+		d.b = append(d.b, "{...}"...)
+		return
+	}
+	d.b = append(d.b, "{\n"...)
+	for i := 0; i < n; i++ {
+		d.indent(depth + 1)
+		elem(i)
+		d.b = append(d.b, ",\n"...)
+	}
+	d.indent(depth)
+	d.b = append(d.b, '}')
+}
+
+// indent appends the indentation of the nesting level depth.
+func (d *dumper) indent(depth int) {
+	for i := 0; i < depth; i++ {
+		d.b = append(d.b, dumpIndent...)
+	}
+}
@@ -0,0 +1,61 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// dumpNode is a value with a cycle for TestDump.
+type dumpNode struct {
+	Name  string
+	Next  *dumpNode
+	Attrs map[string]any
+	Data  []byte
+	level Level
+}
+
+func TestDump(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	n := &dumpNode{Name: "root", Attrs: map[string]any{"b": []int{1}, "a": errors.New("failed")}, Data: []byte("hi"), level: LevelInfo}
+	n.Next = n
+	l.Dump(LevelDebug, "node", n)
+	expected := `[Debug] - node: &logger.dumpNode{
+  Name: "root",
+  Next: <cycle *logger.dumpNode>,
+  Attrs: map[string]interface {}{
+    "a": *errors.errorString("failed"),
+    "b": []int{
+      1,
+    },
+  },
+  Data: []uint8("hi"),
+  level: logger.Level(7),
+}
+`
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	b.Reset()
+	l.Dump(LevelTrace, "filtered", n)
+	l.Dump(LevelDebug, "nil", nil)
+	if b.String() != "[Debug] - nil: nil\n" {
+		t.Errorf("Expected %q. Got %q", "[Debug] - nil: nil\n", b.String())
+	}
+}
+
+func TestDumpDepth(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	var v any = 1
+	for i := 0; i < maxDumpDepth+2; i++ {
+		v = []any{v}
+	}
+	l.Dump(LevelDebug, "deep", v)
+	if !strings.Contains(b.String(), "[]interface {}{...}") || strings.Contains(b.String(), " 1,") {
+		t.Errorf("Expected the dump to be cut at depth %d. Got %q", maxDumpDepth, b.String())
+	}
+}
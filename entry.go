@@ -0,0 +1,139 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+// Entry builds a single record with fields attached to it, without creating a child logger:
+//
+//	l.At(logger.LevelWarning).With("user", id).Msgf("login failed: %v", err)
+//
+// An Entry is created by At and must not be used after Msg or Msgf was called.
+// A nil Entry is valid and discards everything, it is returned by At for disabled loglevels.
+//
+// The arguments of variadic methods like Debug or DebugKV are converted to interfaces before the loglevel is checked,
+// which allocates for values that are not constants. The typed methods of Entry like Str or Int take their values as is,
+// so a record of a disabled loglevel built with them and Msg never allocates:
+//
+//	l.At(logger.LevelDebug).Str("path", path).Int("status", status).Msg("request")
+type Entry struct {
+	l      *Logger
+	level  Level
+	fields []Field
+}
+
+// At starts a record of the given level. If the Logger does not write records of level, At returns nil,
+// so the fields and arguments of the record are never processed.
+func (l *Logger) At(level Level) *Entry {
+	if !l.Enabled(level) {
+		return nil
+	}
+	return &Entry{l: l, level: level}
+}
+
+// Msg writes the record with msg as its message.
+func (e *Entry) Msg(msg string) (n int, err error) {
+	if e == nil {
+		return 0, nil
+	}
+	return e.l.Output(Record{Level: e.level, Message: msg, Fields: e.fields})
+}
+
+// Msgf writes the record with a formatted message, see Printf.
+func (e *Entry) Msgf(format string, a ...any) (n int, err error) {
+	if e == nil {
+		return 0, nil
+	}
+	return e.l.Output(Record{Level: e.level, Message: fmt.Sprintf(format, resolveLazy(a)...), Fields: e.fields})
+}
+
+// With attaches the key/value pairs kv to the record, like the KV methods do.
+func (e *Entry) With(kv ...any) *Entry {
+	if e == nil {
+		return nil
+	}
+	e.fields = append(e.fields, fieldsFromKV(kv)...)
+	return e
+}
+
+// Str attaches a field with a string value to the record.
+func (e *Entry) Str(key, value string) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(key, value)
+}
+
+// Int attaches a field with an int value to the record.
+func (e *Entry) Int(key string, value int) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(key, value)
+}
+
+// Int64 attaches a field with an int64 value to the record.
+func (e *Entry) Int64(key string, value int64) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(key, value)
+}
+
+// Uint64 attaches a field with a uint64 value to the record.
+func (e *Entry) Uint64(key string, value uint64) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(key, value)
+}
+
+// Float64 attaches a field with a float64 value to the record.
+func (e *Entry) Float64(key string, value float64) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(key, value)
+}
+
+// Bool attaches a field with a bool value to the record.
+func (e *Entry) Bool(key string, value bool) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(key, value)
+}
+
+// Dur attaches a field with a time.Duration value to the record.
+func (e *Entry) Dur(key string, value time.Duration) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(key, value)
+}
+
+// Time attaches a field with a time.Time value to the record.
+func (e *Entry) Time(key string, value time.Time) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(key, value)
+}
+
+// Err attaches err to the record like the field returned by Err.
+func (e *Entry) Err(err error) *Entry {
+	if e == nil {
+		return nil
+	}
+	return e.field(ErrorKey, err)
+}
+
+// field attaches a field to the record. The typed methods check for a nil Entry themselves,
+// so their values are only converted to interfaces for records that are written.
+func (e *Entry) field(key string, value any) *Entry {
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
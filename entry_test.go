@@ -0,0 +1,64 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEntry(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.At(LevelWarning).With("user", 7).With(Err(errors.New("bad password"))).Msgf("login failed after %d attempts", 3)
+	l.At(LevelInfo).Msg("plain")
+	if n, err := l.At(LevelDebug).With("expensive", Lazy(func() any {
+		t.Error("Fields of a disabled record were evaluated")
+		return nil
+	})).Msg("hidden"); n != 0 || err != nil {
+		t.Errorf("Expected a discarded record. Got %d, %v", n, err)
+	}
+	expected := "[Warning] - login failed after 3 attempts - user=7 error=\"bad password\"\n" +
+		"[Info] - plain\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestEntryCaller(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetReportCaller(true)
+	l.At(LevelInfo).Msg("here")
+	if !strings.Contains(b.String(), "entry_test.go:") {
+		t.Errorf("Expected the caller in %q", b.String())
+	}
+}
+
+func TestEntryTyped(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.At(LevelInfo).Str("path", "/index.html").Int("status", 200).Int64("size", -1).Uint64("id", 7).
+		Float64("ratio", 0.5).Bool("cached", true).Dur("took", 3*time.Millisecond).Err(errors.New("none")).Msg("request")
+	expected := "[Info] - request - path=/index.html status=200 size=-1 id=7 ratio=0.5 cached=true took=3ms error=none\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestEntryAllocs(t *testing.T) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	path := strings.Repeat("/index.html", 2)
+	status := len(path) * 1000
+	now := time.Now()
+	if allocs := testing.AllocsPerRun(100, func() {
+		l.At(LevelDebug).Str("path", path).Int("status", status).Int64("size", int64(status)).Uint64("id", uint64(status)).
+			Float64("ratio", float64(status)).Bool("cached", status > 0).Dur("took", time.Duration(status)).
+			Time("at", now).Err(io.EOF).Msg(path)
+	}); allocs > 0 {
+		t.Errorf("Expected no allocations for a disabled record, got %.0f", allocs)
+	}
+}
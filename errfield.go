@@ -0,0 +1,56 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Keys of the fields that describe an error.
+const (
+	ErrorKey      = "error"       //The error's message.
+	ErrorChainKey = "error.chain" //The messages of the errors wrapped by the error, outermost first.
+	ErrorStackKey = "error.stack" //The error's stack trace.
+)
+
+// Err returns a field with the key "error" that holds err. It can be passed in place of a key/value pair
+// to the KV methods:
+//
+//	l.ErrorKV("request failed", logger.Err(err), "path", path)
+func Err(err error) Field {
+	return Field{Key: ErrorKey, Value: err}
+}
+
+// ErrorE sends msg with loglevel LevelError to the Logger and attaches err as fields. Besides the error's
+// message, the messages of the errors it wraps via %w are attached as "error.chain". If err or an error it wraps
+// implements fmt.Formatter and prints more details for the verb %+v, like the stack trace of errors created
+// by github.com/pkg/errors, these details are attached as "error.stack". If err is nil, only msg is sent.
+func (l *Logger) ErrorE(err error, msg string) (int, error) {
+	if !l.Enabled(LevelError) {
+		return 0, nil
+	}
+	return l.Output(Record{Level: LevelError, Message: msg, Fields: errorFields(err)})
+}
+
+// errorFields returns the fields that describe err.
+func errorFields(err error) []Field {
+	if err == nil {
+		return nil
+	}
+	fields := []Field{Err(err)}
+	var chain []string
+	for wrapped := errors.Unwrap(err); wrapped != nil; wrapped = errors.Unwrap(wrapped) {
+		chain = append(chain, wrapped.Error())
+	}
+	if len(chain) > 0 {
+		fields = append(fields, Field{Key: ErrorChainKey, Value: chain})
+	}
+	var formatter fmt.Formatter
+	if errors.As(err, &formatter) {
+		if stack := fmt.Sprintf("%+v", formatter); stack != formatter.(error).Error() {
+			fields = append(fields, Field{Key: ErrorStackKey, Value: stack})
+		}
+	}
+	return fields
+}
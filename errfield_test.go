@@ -0,0 +1,66 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// stackError mimics errors that print their stack trace for the verb %+v.
+type stackError struct{ msg string }
+
+func (e *stackError) Error() string { return e.msg }
+
+func (e *stackError) Format(s fmt.State, verb rune) {
+	fmt.Fprint(s, e.msg)
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, "\nmain.main\n\tmain.go:12")
+	}
+}
+
+func TestErr(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.ErrorKV("request failed", Err(errors.New("timeout")), "path", "/")
+	expected := "[Error] - request failed - error=timeout path=/\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestErrorE(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetFormat(FormatJSON)
+	cause := &stackError{msg: "connection refused"}
+	l.ErrorE(fmt.Errorf("query users: %w", fmt.Errorf("dial: %w", cause)), "request failed")
+	var rec struct {
+		Message string         `json:"message"`
+		Fields  map[string]any `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(b.String()), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Message != "request failed" {
+		t.Errorf("Expected %q. Got %q", "request failed", rec.Message)
+	}
+	if msg := rec.Fields[ErrorKey]; msg != "query users: dial: connection refused" {
+		t.Errorf("Expected %q. Got %q", "query users: dial: connection refused", msg)
+	}
+	if chain := fmt.Sprint(rec.Fields[ErrorChainKey]); chain != "[dial: connection refused connection refused]" {
+		t.Errorf("Unexpected error chain %q", chain)
+	}
+	if stack, _ := rec.Fields[ErrorStackKey].(string); !strings.Contains(stack, "main.go:12") {
+		t.Errorf("Expected a stack trace. Got %q", stack)
+	}
+	b.Reset()
+	l.SetFormat(FormatText)
+	l.ErrorE(nil, "no error")
+	if b.String() != "[Error] - no error\n" {
+		t.Errorf("Expected %q. Got %q", "[Error] - no error\n", b.String())
+	}
+}
@@ -0,0 +1,132 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"time"
+)
+
+// ErrorPolicy decides what happens with a record that could not be written to a destination.
+type ErrorPolicy interface {
+	// Recover is called with the destination w, the rendered record b and the error that occurred while
+	// writing b to w. It returns nil if the record could be delivered after all, otherwise an error.
+	Recover(w io.Writer, b []byte, err error) error
+}
+
+// dropPolicy gives up on a record that could not be written.
+type dropPolicy struct{}
+
+// DropPolicy returns an ErrorPolicy that drops a record that could not be written. This is the default behaviour.
+func DropPolicy() ErrorPolicy {
+	return dropPolicy{}
+}
+
+func (dropPolicy) Recover(w io.Writer, b []byte, err error) error {
+	return err
+}
+
+// retryPolicy writes a record again until it succeeds or the attempts are exhausted.
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// NewRetryPolicy returns an ErrorPolicy that tries to write a failed record up to attempts more times, waiting
+// backoff before each attempt. The whole record is written again, even if the failed write was partially successful.
+// As the Logger is locked while retrying, a long backoff stalls all goroutines that log.
+func NewRetryPolicy(attempts int, backoff time.Duration) ErrorPolicy {
+	if attempts < 1 || backoff < 0 {
+		panic("Programming error: logger.NewRetryPolicy: Passed attempts less than 1 or negative backoff")
+	}
+	return &retryPolicy{attempts: attempts, backoff: backoff}
+}
+
+func (p *retryPolicy) Recover(w io.Writer, b []byte, err error) error {
+	for i := 0; i < p.attempts; i++ {
+		if p.backoff > 0 {
+			time.Sleep(p.backoff)
+		}
+		if _, err = w.Write(b); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// fallbackPolicy writes a record to another writer if it could not be written.
+type fallbackPolicy struct {
+	w io.Writer
+}
+
+// NewFallbackPolicy returns an ErrorPolicy that writes a failed record to w instead, e.g. os.Stderr.
+func NewFallbackPolicy(w io.Writer) ErrorPolicy {
+	if w == nil {
+		panic("Programming error: logger.NewFallbackPolicy: Passed nil as writer")
+	}
+	return &fallbackPolicy{w: w}
+}
+
+func (p *fallbackPolicy) Recover(w io.Writer, b []byte, err error) error {
+	_, err = p.w.Write(b)
+	return err
+}
+
+// SetErrorHandler sets a function that is called with every error that occurs while writing a record and
+// that could not be recovered by the Logger's error policy. The handler is called while the Logger is locked,
+// records it sends to the same Logger are written before the lock is released. Passing nil removes the handler.
+func (l *Logger) SetErrorHandler(handler func(error)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errorHandler = handler
+}
+
+// SetErrorPolicy sets the ErrorPolicy that is applied to records that could not be written to one of the
+// Logger's destinations. Passing nil restores the default policy, which drops the record.
+func (l *Logger) SetErrorPolicy(policy ErrorPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errorPolicy = policy
+}
+
+// writeTo writes the rendered record b to w. If writing fails, the Logger's error policy is applied and an
+// unrecovered error is passed to the Logger's error handler. A write that exceeds the write timeout is dropped
+// without applying the error policy. The policy is not applied either if only some writers of a MultiWriter failed,
+// as the record already reached the others. The caller must hold the Logger's lock.
+func (l *Logger) writeTo(dest destination, w io.Writer, b []byte) (n int, err error) {
+	if l.writeTimeout > 0 {
+		n, err = l.writeWithTimeout(dest, w, b)
+	} else {
+		n, err = w.Write(b)
+	}
+	l.bytesWritten.Add(uint64(n))
+	if err == nil {
+		return n, nil
+	}
+	if err == ErrWriteTimeout {
+		l.dropped.Add(1)
+		l.handleError(err)
+		return n, err
+	}
+	if _, partial := err.(*MultiWriteError); partial && n == len(b) {
+		l.writeErrors.Add(1)
+		l.handleError(err)
+		return n, err
+	}
+	if l.errorPolicy != nil {
+		if err = l.errorPolicy.Recover(w, b, err); err == nil {
+			l.bytesWritten.Add(uint64(len(b) - n))
+			return len(b), nil
+		}
+	}
+	l.writeErrors.Add(1)
+	l.handleError(err)
+	return n, err
+}
+
+// handleError passes err to the Logger's error handler if one is set. The caller must hold the Logger's lock.
+func (l *Logger) handleError(err error) {
+	if l.errorHandler != nil {
+		l.errorHandler(err)
+	}
+}
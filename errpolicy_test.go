@@ -0,0 +1,58 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// flakyWriter fails the first failures writes.
+type flakyWriter struct {
+	failures int
+	b        strings.Builder
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	if w.failures > 0 {
+		w.failures--
+		return 0, errors.New("temporary failure")
+	}
+	return w.b.Write(p)
+}
+
+func TestErrorHandler(t *testing.T) {
+	var errs []error
+	l := New(failingWriter{}, LevelDebug, loglevelDelimiter)
+	l.SetErrorHandler(func(err error) { errs = append(errs, err) })
+	l.Info("lost")
+	if len(errs) != 1 {
+		t.Errorf("Expected 1 error, got %d", len(errs))
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	w := &flakyWriter{failures: 2}
+	l := New(w, LevelDebug, loglevelDelimiter)
+	l.SetErrorPolicy(NewRetryPolicy(2, 0))
+	l.SetErrorHandler(func(err error) { t.Errorf("Unexpected error: %s", err) })
+	if n, err := l.Info("retried"); err != nil || n != len("[Info] - retried\n") {
+		t.Errorf("Expected (%d, nil), got (%d, %v)", len("[Info] - retried\n"), n, err)
+	}
+	if w.b.String() != "[Info] - retried\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - retried\n", w.b.String())
+	}
+}
+
+func TestFallbackPolicy(t *testing.T) {
+	fallback := new(strings.Builder)
+	l := New(failingWriter{}, LevelDebug, loglevelDelimiter)
+	l.SetErrorPolicy(NewFallbackPolicy(fallback))
+	if _, err := l.Info("rescued"); err != nil {
+		t.Error(err)
+	}
+	if fallback.String() != "[Info] - rescued\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - rescued\n", fallback.String())
+	}
+}
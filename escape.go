@@ -0,0 +1,90 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bytes"
+	"strings"
+)
+
+// escapeChar precedes escaped characters in the segments of an escaping Logger.
+const escapeChar = '\\'
+
+// EscapeDelimiter returns true if the Logger escapes the delimiter within the segments of its records.
+func (l *Logger) EscapeDelimiter() bool {
+	defer l.runlock(l.rlock())
+	return l.escapeDelimiter
+}
+
+// SetEscapeDelimiter enables or disables the escaping of the delimiter in FormatText. If enabled, a backslash
+// within a segment is written as two backslashes and every occurrence of the delimiter within a segment is
+// preceded by a backslash, so that records can always be split into their segments by SplitRecord.
+func (l *Logger) SetEscapeDelimiter(enable bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.escapeDelimiter = enable
+}
+
+// SplitRecord splits a line written in FormatText by a Logger that escapes its delimiter into its segments
+// and removes the escaping. A stack trace following the line must not be passed.
+func SplitRecord(line, delimiter string) []string {
+	if len(delimiter) < 1 {
+		panic("Programming error: logger.SplitRecord: Passed empty string as delimiter")
+	}
+	var segs []string
+	seg := new(strings.Builder)
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == escapeChar && i+1 < len(line):
+			i++
+			seg.WriteByte(line[i])
+		case strings.HasPrefix(line[i:], delimiter):
+			segs = append(segs, seg.String())
+			seg.Reset()
+			i += len(delimiter) - 1
+		default:
+			seg.WriteByte(line[i])
+		}
+	}
+	return append(segs, seg.String())
+}
+
+// escapeSegment escapes backslashes and the delimiter in b[start:], the rendered segment of a record.
+// As segments are followed by the delimiter, the end of a segment that forms the delimiter together with
+// the following delimiter is escaped as well.
+func escapeSegment(b []byte, start int, delimiter string) []byte {
+	seg := b[start:]
+	if !needsEscape(seg, delimiter) {
+		return b
+	}
+	seg = append([]byte(nil), seg...)
+	b = b[:start]
+	for i := range seg {
+		if seg[i] == escapeChar || startsDelimiter(seg[i:], delimiter) {
+			b = append(b, escapeChar)
+		}
+		b = append(b, seg[i])
+	}
+	return b
+}
+
+// needsEscape returns true if seg contains a character escapeSegment has to escape.
+func needsEscape(seg []byte, delimiter string) bool {
+	if bytes.IndexByte(seg, escapeChar) >= 0 || bytes.Contains(seg, []byte(delimiter)) {
+		return true
+	}
+	for i := len(seg) - len(delimiter) + 1; i < len(seg); i++ {
+		if i >= 0 && startsDelimiter(seg[i:], delimiter) {
+			return true
+		}
+	}
+	return false
+}
+
+// startsDelimiter returns true if tail followed by delimiter starts with delimiter.
+func startsDelimiter(tail []byte, delimiter string) bool {
+	if len(tail) >= len(delimiter) {
+		return string(tail[:len(delimiter)]) == delimiter
+	}
+	return string(tail) == delimiter[:len(tail)] && delimiter[len(tail):] == delimiter[:len(delimiter)-len(tail)]
+}
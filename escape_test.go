@@ -0,0 +1,41 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEscapeDelimiter(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetEscapeDelimiter(true)
+	l.InfoKV(`a - b\c -`, "k", "v - w")
+	expected := `[Info] - a\ - b\\c\ - - k="v\ - w"` + "\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	segs := SplitRecord(strings.TrimSuffix(b.String(), "\n"), loglevelDelimiter)
+	if want := []string{"[Info]", `a - b\c -`, `k="v - w"`}; !reflect.DeepEqual(segs, want) {
+		t.Errorf("Expected %q. Got %q", want, segs)
+	}
+	s := NewScanner(strings.NewReader(b.String()), FormatText)
+	s.SetEscaped(true)
+	if !s.Scan() {
+		t.Fatal(s.Err())
+	}
+	if rec := s.Record(); rec.Message != `a - b\c -` || !reflect.DeepEqual(rec.Fields, []Field{{"k", "v - w"}}) {
+		t.Errorf("Unexpected record %+v", rec)
+	}
+}
+
+func TestEscapeSegment(t *testing.T) {
+	for _, seg := range []string{"", "plain", "---", "a--b", `\--`, "-"} {
+		line := string(escapeSegment([]byte(seg), 0, "--")) + "--next"
+		if got := SplitRecord(line, "--"); !reflect.DeepEqual(got, []string{seg, "next"}) {
+			t.Errorf("Expected %q. Got %q", []string{seg, "next"}, got)
+		}
+	}
+}
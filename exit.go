@@ -0,0 +1,143 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"os"
+)
+
+// defaultExitCode is the exit code of Die and Dief unless another one is set by SetExitCode.
+const defaultExitCode = 1
+
+// assertExitCode panics if code is not an exit code between 1 and 125. Greater codes have special meanings to shells.
+func assertExitCode(code int, function string) {
+	if code < 1 || code > 125 {
+		panic("Programming error: (l *Logger) " + function + "(): Passed exit code outside of 1 to 125")
+	}
+}
+
+// DieCode sends a message of loglevel LevelPanic to the Logger, runs the exit hooks, then exits with code, so
+// supervisors can tell different kinds of failures apart. Passing a code outside of 1 to 125 will cause a panic.
+func (l *Logger) DieCode(code int, v ...any) {
+	assertExitCode(code, "DieCode")
+	l.Panic(v...)
+	l.exit(code)
+}
+
+// DiefCode sends a formatted message of loglevel LevelPanic to the Logger, runs the exit hooks, then exits with code.
+// Passing a code outside of 1 to 125 will cause a panic.
+func (l *Logger) DiefCode(code int, format string, a ...any) {
+	assertExitCode(code, "DiefCode")
+	l.Panicf(format, a...)
+	l.exit(code)
+}
+
+// ExitCode returns the exit code of Die and Dief.
+func (l *Logger) ExitCode() int {
+	defer l.runlock(l.rlock())
+	if l.exitCode == 0 {
+		return defaultExitCode
+	}
+	return l.exitCode
+}
+
+// RegisterExitHook registers a function that is called by Die and Dief after the record has been logged
+// and before the program exits. Hooks are called in reverse order of their registration, like deferred functions.
+// Hooks can be used to flush buffers, close files or send telemetry.
+func (l *Logger) RegisterExitHook(hook func()) {
+	if hook == nil {
+		panic("Programming error: (l *Logger) RegisterExitHook(): Passed nil as hook")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.exitHooks = append(l.exitHooks, hook)
+}
+
+// SetExitCode sets the exit code of Die and Dief, which defaults to 1. Passing a code outside of 1 to 125 will cause a panic.
+func (l *Logger) SetExitCode(code int) {
+	assertExitCode(code, "SetExitCode")
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.exitCode = code
+}
+
+// SetExitFunc replaces the function Die and Dief call to terminate the program, which defaults to os.Exit.
+// Tests can use it to intercept the exit. Passing nil restores os.Exit.
+func (l *Logger) SetExitFunc(exit func(code int)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.exitFunc = exit
+}
+
+// exitRequest is the panic value of Die and Dief in deferred exit mode, see SetDeferredExit.
+type exitRequest struct {
+	l    *Logger
+	code int
+}
+
+// Error describes the exit request, it is shown if the panic is not recovered by Main.
+func (r *exitRequest) Error() string {
+	return fmt.Sprintf("logger: exit with code %d requested in deferred exit mode outside of logger.Main", r.code)
+}
+
+// DeferredExit returns true if Die and Dief exit by a panic that is recovered by Main.
+func (l *Logger) DeferredExit() bool {
+	defer l.runlock(l.rlock())
+	return l.deferredExit
+}
+
+// SetDeferredExit enables or disables the deferred exit mode. Exiting immediately skips the deferred functions of
+// the program, e.g. the removal of temporary files. In deferred exit mode, Die, Dief and the other methods that exit
+// the program panic with a value that is recovered by Main instead. The panic unwinds the stack and runs the deferred
+// functions, then Main runs the exit hooks and exits. Die must be called by the goroutine that runs Main, the panic
+// must not be recovered by other code. RecoverAndLog, RecoverAndRepanic and HandleCrash let it pass.
+func (l *Logger) SetDeferredExit(enable bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.deferredExit = enable
+}
+
+// Main calls main and returns when it returns. If a Logger in deferred exit mode, see SetDeferredExit, requests
+// an exit while main runs, Main runs the exit hooks of that Logger after main's deferred functions and exits.
+// Other panics continue. Main is meant to wrap the body of a program's main function:
+//
+//	func main() {
+//		logger.Main(run)
+//	}
+func Main(main func()) {
+	defer func() {
+		if v := recover(); v != nil {
+			r, ok := v.(*exitRequest)
+			if !ok {
+				panic(v)
+			}
+			r.l.terminate(r.code)
+		}
+	}()
+	main()
+}
+
+// exit flushes the Logger and terminates the program with code. In deferred exit mode, it panics with an exit request instead.
+func (l *Logger) exit(code int) {
+	l.Flush()
+	if l.DeferredExit() {
+		panic(&exitRequest{l: l, code: code})
+	}
+	l.terminate(code)
+}
+
+// terminate runs the exit hooks and terminates the program with code.
+func (l *Logger) terminate(code int) {
+	l.mu.Lock()
+	hooks := l.exitHooks
+	exit := l.exitFunc
+	l.mu.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+	if exit == nil {
+		exit = os.Exit
+	}
+	exit(code)
+}
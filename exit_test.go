@@ -0,0 +1,93 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDieExitHooks(t *testing.T) {
+	var calls []string
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.RegisterExitHook(func() { calls = append(calls, "first") })
+	l.RegisterExitHook(func() { calls = append(calls, "second") })
+	l.SetExitFunc(func(code int) {
+		calls = append(calls, "exit")
+		if code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+	})
+	l.Dief("fatal: %s", "disk full")
+	expect := "[Panic] - fatal: disk full\n"
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+	if strings.Join(calls, ",") != "second,first,exit" {
+		t.Errorf("Unexpected call order: %v", calls)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	var codes []int
+	l := New(new(strings.Builder), LevelInfo, loglevelDelimiter)
+	l.SetExitFunc(func(code int) { codes = append(codes, code) })
+	l.DieCode(3, "config invalid")
+	l.SetExitCode(75)
+	l.Die("temporary failure")
+	l.DiefCode(4, "%s", "unavailable")
+	if len(codes) != 3 || codes[0] != 3 || codes[1] != 75 || codes[2] != 4 || l.ExitCode() != 75 {
+		t.Errorf("Unexpected exit codes %v", codes)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for exit code 0")
+		}
+	}()
+	l.SetExitCode(0)
+}
+
+func TestPanicNow(t *testing.T) {
+	const msg = "invariant violated: 3 > 2"
+	b := new(strings.Builder)
+	l := New(b, LevelPanic, loglevelDelimiter)
+	defer func() {
+		r := recover()
+		if r != msg {
+			t.Errorf("Expected panic value %q, got %v", msg, r)
+		}
+		expect := "[Panic] - " + msg + "\n"
+		if b.String() != expect {
+			t.Errorf("Expected %q. Got %q", expect, b.String())
+		}
+	}()
+	l.PanicNowf("invariant violated: %d > %d", 3, 2)
+}
+
+func TestMainDeferredExit(t *testing.T) {
+	var calls []string
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetDeferredExit(true)
+	l.RegisterExitHook(func() { calls = append(calls, "hook") })
+	l.SetExitFunc(func(code int) { calls = append(calls, "exit "+strconv.Itoa(code)) })
+	Main(func() {
+		defer func() { calls = append(calls, "cleanup") }()
+		func() {
+			defer RecoverAndLog(l)
+			l.DieCode(3, "fatal")
+		}()
+		calls = append(calls, "not reached")
+	})
+	if strings.Join(calls, ",") != "cleanup,hook,exit 3" || b.String() != "[Panic] - fatal\n" {
+		t.Errorf("Unexpected calls %v and output %q", calls, b.String())
+	}
+	defer func() {
+		if v := recover(); v != "other" {
+			t.Errorf("Expected the panic %q to continue. Got %v", "other", v)
+		}
+	}()
+	Main(func() { panic("other") })
+}
@@ -0,0 +1,258 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// badKey is used as the key of a value that was passed without a key.
+const badKey = "!BADKEY"
+
+// TagKey is the key of the field that holds the tag of a Logger created by WithTag.
+const TagKey = "tag"
+
+// Field is a key/value pair that carries machine-readable information attached to a log record.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// fieldsFromKV converts alternating keys and values into a slice of fields. A Field in place of a key is taken
+// as is, without a value. Keys that are not strings are converted via fmt.Sprint. A trailing value without
+// a partner is stored with the key "!BADKEY".
+func fieldsFromKV(kv []any) []Field {
+	if len(kv) < 1 {
+		return nil
+	}
+	fields := make([]Field, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		if f, ok := kv[i].(Field); ok {
+			fields = append(fields, f)
+			i--
+			continue
+		}
+		if i+1 >= len(kv) {
+			fields = append(fields, Field{Key: badKey, Value: kv[i]})
+			break
+		}
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+		fields = append(fields, Field{Key: key, Value: kv[i+1]})
+	}
+	return fields
+}
+
+// String returns the field as key=value. Keys and values that contain whitespace, quotes, equal signs
+// or non-printable characters are quoted.
+func (f Field) String() string {
+	return string(f.appendText(nil))
+}
+
+// appendText appends the field as key=value to b.
+func (f Field) appendText(b []byte) []byte {
+	b = appendQuotedIfNeeded(b, f.Key)
+	b = append(b, '=')
+	return appendFieldValue(b, f.Value)
+}
+
+// appendFieldValue appends the text representation of a field's value to b, quoted like appendQuotedIfNeeded does.
+// Strings, integers and booleans are appended without formatting them through fmt.
+func appendFieldValue(b []byte, v any) []byte {
+	switch v := resolveValue(v).(type) {
+	case string:
+		return appendQuotedIfNeeded(b, v)
+	case int:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case uint:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(b, v, 10)
+	case bool:
+		return strconv.AppendBool(b, v)
+	case error:
+		return appendQuotedIfNeeded(b, v.Error())
+	default:
+		return appendQuotedIfNeeded(b, fmt.Sprint(v))
+	}
+}
+
+// fieldValueString returns the text representation of a field's value.
+func fieldValueString(v any) string {
+	v = resolveValue(v)
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprint(v)
+}
+
+// quoteIfNeeded returns s as a quoted go string literal if s would be ambiguous in a key=value pair.
+func quoteIfNeeded(s string) string {
+	if !needsQuote(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+// appendQuotedIfNeeded appends s to b, quoted like quoteIfNeeded does.
+func appendQuotedIfNeeded(b []byte, s string) []byte {
+	if !needsQuote(s) {
+		return append(b, s...)
+	}
+	return strconv.AppendQuote(b, s)
+}
+
+// needsQuote returns true if s would be ambiguous in a key=value pair.
+func needsQuote(s string) bool {
+	return len(s) < 1 || strings.IndexFunc(s, func(r rune) bool {
+		return r == '"' || r == '=' || unicode.IsSpace(r) || !unicode.IsPrint(r)
+	}) >= 0
+}
+
+// textFields renders fields as a space separated list of key=value pairs.
+func textFields(fields []Field) string {
+	return string(appendTextFields(nil, fields...))
+}
+
+// appendTextFields appends fields to b as a space separated list of key=value pairs.
+func appendTextFields(b []byte, fields ...Field) []byte {
+	for i, f := range fields {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		b = f.appendText(b)
+	}
+	return b
+}
+
+// jsonFields is a list of fields that is encoded as a JSON object, preserving the order of the fields.
+type jsonFields []Field
+
+func (fields jsonFields) MarshalJSON() ([]byte, error) {
+	b := []byte{'{'}
+	for i, f := range fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, key...)
+		b = append(b, ':')
+		b = append(b, jsonFieldValue(f.Value)...)
+	}
+	return append(b, '}'), nil
+}
+
+// jsonFieldValue encodes a field's value as JSON. Errors are encoded as their message, values that
+// cannot be encoded are encoded as their text representation.
+func jsonFieldValue(v any) []byte {
+	v = resolveValue(v)
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return b
+}
+
+// AlertKV sends a message of loglevel LevelAlert with the key/value pairs kv attached to the Logger.
+func (l *Logger) AlertKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelAlert, msg, kv...)
+}
+
+// CriticalKV sends a message of loglevel LevelCritical with the key/value pairs kv attached to the Logger.
+func (l *Logger) CriticalKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelCritical, msg, kv...)
+}
+
+// DebugKV sends a message of loglevel LevelDebug with the key/value pairs kv attached to the Logger.
+func (l *Logger) DebugKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelDebug, msg, kv...)
+}
+
+// ErrorKV sends a message of loglevel LevelError with the key/value pairs kv attached to the Logger.
+func (l *Logger) ErrorKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelError, msg, kv...)
+}
+
+// InfoKV sends a message of loglevel LevelInfo with the key/value pairs kv attached to the Logger.
+func (l *Logger) InfoKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelInfo, msg, kv...)
+}
+
+// NoticeKV sends a message of loglevel LevelNotice with the key/value pairs kv attached to the Logger.
+func (l *Logger) NoticeKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelNotice, msg, kv...)
+}
+
+// PanicKV sends a message of loglevel LevelPanic with the key/value pairs kv attached to the Logger.
+// Please note that it does NOT call panic()!
+func (l *Logger) PanicKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelPanic, msg, kv...)
+}
+
+// PrintKV writes the log message with the key/value pairs kv attached if its log level is equally severe
+// or more severe than that set for the Logger. kv must consist of alternating keys and values, keys should be strings.
+// In FormatText the pairs are appended to the message as key=value, in FormatJSON they are stored in the object "fields".
+func (l *Logger) PrintKV(level Level, msg string, kv ...any) (n int, err error) {
+	if !l.Enabled(level) {
+		return 0, nil
+	}
+	return l.Output(Record{Level: level, Message: msg, Fields: fieldsFromKV(kv)})
+}
+
+// TraceKV sends a message of loglevel LevelTrace with the key/value pairs kv attached to the Logger.
+func (l *Logger) TraceKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelTrace, msg, kv...)
+}
+
+// WarningKV sends a message of loglevel LevelWarning with the key/value pairs kv attached to the Logger.
+func (l *Logger) WarningKV(msg string, kv ...any) (n int, err error) {
+	return l.PrintKV(LevelWarning, msg, kv...)
+}
+
+// WithFields returns a child logger that attaches fields to all its records, preceding the fields passed
+// with the record. The keys are sorted to give the fields a stable order. Like WithPrefix, the child shares
+// the writer, the lock and all settings with l. If l already has fields, the child has l's fields and fields.
+func (l *Logger) WithFields(fields map[string]any) *Logger {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	child := *l
+	child.fields = make([]Field, len(l.fields), len(l.fields)+len(keys))
+	copy(child.fields, l.fields)
+	for _, key := range keys {
+		child.fields = append(child.fields, Field{Key: key, Value: fields[key]})
+	}
+	return &child
+}
+
+// WithTag returns a child logger that attaches the field "tag" with value tag to all its records, in front of
+// all other fields. It attributes the records of a worker, e.g. a goroutine of a pool, to that worker.
+// Calling WithTag on a tagged Logger replaces the tag. The child shares the writer, the lock and all settings with l.
+func (l *Logger) WithTag(tag string) *Logger {
+	child := *l
+	child.fields = make([]Field, 1, len(l.fields)+1)
+	child.fields[0] = Field{Key: TagKey, Value: tag}
+	for _, f := range l.fields {
+		if f.Key != TagKey {
+			child.fields = append(child.fields, f)
+		}
+	}
+	return &child
+}
@@ -0,0 +1,88 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPrintKVText(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.InfoKV("request served", "user", "bob", "path", "/a b", "status", 200, "err", errors.New("none"), "dangling")
+	expect := fmt.Sprintf("[%s]%srequest served%s%s\n",
+		LevelInfo.String(),
+		loglevelDelimiter,
+		loglevelDelimiter,
+		`user=bob path="/a b" status=200 err=none !BADKEY=dangling`)
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
+
+func TestPrintKVJSON(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetFormat(FormatJSON)
+	l.ErrorKV("request failed", "status", 500, "err", errors.New("timeout"), 7, true)
+	rec := make(map[string]any)
+	if err := json.Unmarshal([]byte(b.String()), &rec); err != nil {
+		t.Fatal(err)
+	}
+	fields, ok := rec["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("Record %q does not contain fields", b.String())
+	}
+	expect := map[string]any{"status": float64(500), "err": "timeout", "7": true}
+	for k, v := range expect {
+		if fields[k] != v {
+			t.Errorf("Expected field %q to be %v, got %v", k, v, fields[k])
+		}
+	}
+	if !strings.Contains(b.String(), `"fields":{"status":500,"err":"timeout","7":true}`) {
+		t.Errorf("Fields of record %q are not in call order", b.String())
+	}
+}
+
+func TestPrintKVFiltered(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	if n, err := l.DebugKV("invisible", "key", "value"); n != 0 || err != nil {
+		t.Errorf("Expected (0, nil), got (%d, %v)", n, err)
+	}
+	if b.String() != "" {
+		t.Errorf("Record %q should not have been printed", b.String())
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	req := l.WithFields(map[string]any{"request": 7, "method": "GET"})
+	req.WithPrefix("db").WithFields(map[string]any{"table": "users"}).InfoKV("query", "rows", 3)
+	req.Info("done")
+	l.Info("unrelated")
+	expected := "[Info] - db: query - method=GET request=7 table=users rows=3\n" +
+		"[Info] - done - method=GET request=7\n" +
+		"[Info] - unrelated\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestWithTag(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	worker := l.WithFields(map[string]any{"pool": "io"}).WithTag("worker-1")
+	worker.InfoKV("started", "job", 4)
+	worker.WithTag("worker-2").Info("moved")
+	expected := "[Info] - started - tag=worker-1 pool=io job=4\n" +
+		"[Info] - moved - tag=worker-2 pool=io\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,109 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	FilterPass   FilterAction = iota //Leave the decision to the next filter, a record no filter decided on is written.
+	FilterAccept                     //Write the record without consulting the remaining filters.
+	FilterDrop                       //Discard the record.
+)
+
+// Represents the decision of a Filter about a record.
+type FilterAction int
+
+// Filter decides whether a record is written or discarded, see (l *Logger) AddFilter.
+type Filter func(rec *Record) FilterAction
+
+// String returns the name of the FilterAction.
+func (a FilterAction) String() string {
+	switch a {
+	case FilterPass:
+		return "Pass"
+	case FilterAccept:
+		return "Accept"
+	case FilterDrop:
+		return "Drop"
+	}
+	return "Undefined"
+}
+
+// AddFilter adds a Filter that is consulted for every record before the record is transformed, redacted and
+// passed to hooks, sinks and outputs. Filters are consulted in the order
+// they were added until one of them accepts or drops the record. Records of LevelAudit are never filtered.
+// Filters can silence a noisy component temporarily, see ClearFilters:
+//
+//	l.AddFilter(logger.PrefixFilter("thirdparty.cache", logger.FilterDrop))
+//
+// Passing nil as f will cause a panic.
+func (l *Logger) AddFilter(f Filter) {
+	if f == nil {
+		panic("Programming error: (l *Logger) AddFilter(): Passed nil as filter")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.filters = append(l.filters, f)
+}
+
+// ClearFilters removes all filters of the Logger.
+func (l *Logger) ClearFilters() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.filters = nil
+}
+
+// MessageFilter returns a Filter that applies action to records whose message matches re.
+// Passing nil as re will cause a panic.
+func MessageFilter(re *regexp.Regexp, action FilterAction) Filter {
+	if re == nil {
+		panic("Programming error: logger.MessageFilter: Passed nil as regular expression")
+	}
+	return func(rec *Record) FilterAction {
+		if re.MatchString(rec.Message) {
+			return action
+		}
+		return FilterPass
+	}
+}
+
+// PrefixFilter returns a Filter that applies action to the records of the component prefix and of its
+// subcomponents, e.g. "db" matches records with the prefix "db" or "db.sql", see (l *Logger) WithPrefix.
+func PrefixFilter(prefix string, action FilterAction) Filter {
+	return func(rec *Record) FilterAction {
+		if rec.Prefix == prefix || strings.HasPrefix(rec.Prefix, prefix) && strings.HasPrefix(rec.Prefix[len(prefix):], ".") {
+			return action
+		}
+		return FilterPass
+	}
+}
+
+// PredicateFilter returns a Filter that applies action to the records for which match returns true.
+// Passing nil as match will cause a panic.
+func PredicateFilter(match func(rec *Record) bool, action FilterAction) Filter {
+	if match == nil {
+		panic("Programming error: logger.PredicateFilter: Passed nil as predicate")
+	}
+	return func(rec *Record) FilterAction {
+		if match(rec) {
+			return action
+		}
+		return FilterPass
+	}
+}
+
+// filter returns false if one of the Logger's filters drops rec. The caller must hold the Logger's lock.
+func (l *Logger) filter(rec *Record) bool {
+	for _, f := range l.filters {
+		switch f(rec) {
+		case FilterAccept:
+			return true
+		case FilterDrop:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.AddFilter(MessageFilter(regexp.MustCompile(`^important`), FilterAccept))
+	l.AddFilter(PrefixFilter("vendor", FilterDrop))
+	l.AddFilter(PredicateFilter(func(rec *Record) bool { return strings.Contains(rec.Message, "noise") }, FilterDrop))
+	l.Info("kept")
+	l.Info("some noise")
+	l.WithPrefix("vendor").Info("dropped")
+	l.WithPrefix("vendor").WithPrefix("cache").Info("dropped")
+	l.WithPrefix("vendors").Info("kept")
+	l.WithPrefix("vendor").Info("important noise")
+	l.WithPrefix("vendor").Audit("audited")
+	expected := "[Info] - kept\n[Info] - vendors: kept\n[Info] - vendor: important noise\n"
+	if !strings.HasPrefix(b.String(), expected) || !strings.Contains(b.String(), "audited") {
+		t.Errorf("Expected prefix %q and an audit record. Got %q", expected, b.String())
+	}
+	l.ClearFilters()
+	b.Reset()
+	l.Info("some noise")
+	if b.String() != "[Info] - some noise\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - some noise\n", b.String())
+	}
+}
@@ -3,12 +3,41 @@
 package logger
 
 import (
+	"flag"
 	"fmt"
 )
 
-// LevelFlag implements flag.Getter
+// LevelFlag implements flag.Getter and the Value interface of github.com/spf13/pflag.
 type LevelFlag Level
 
+// LevelVar defines a flag with the given name and usage on fs that accepts a loglevel in every form ParseLevel
+// accepts. The returned Level holds the flag's value, def if the flag is not set.
+// Passing nil as fs or an invalid loglevel as def will cause a panic.
+func LevelVar(fs *flag.FlagSet, name string, def Level, usage string) *Level {
+	if fs == nil {
+		panic("Programming error: logger.LevelVar: Passed nil as flag set")
+	}
+	p := new(Level)
+	fs.Var(LevelValue(p, def), name, usage)
+	return p
+}
+
+// LevelValue sets *p to def and returns a flag value that stores the parsed loglevel in *p. It can be
+// passed to the Var method of a flag set of github.com/spf13/pflag:
+//
+//	var level logger.Level
+//	pflag.Var(logger.LevelValue(&level, logger.LevelInfo), "loglevel", "Minimum loglevel")
+//
+// Passing nil as p or an invalid loglevel as def will cause a panic.
+func LevelValue(p *Level, def Level) *LevelFlag {
+	if p == nil {
+		panic("Programming error: logger.LevelValue: Passed nil as level")
+	}
+	assertLoglevel(def)
+	*p = def
+	return (*LevelFlag)(p)
+}
+
 func (f *LevelFlag) Set(arg string) error {
 	lvl, err := ParseLevel(arg)
 	if err != nil {
@@ -25,6 +54,11 @@ func (f *LevelFlag) String() string {
 	return Level(*f).String()
 }
 
+// Type returns the name of the flag's value type for the usage message of github.com/spf13/pflag.
+func (f *LevelFlag) Type() string {
+	return "level"
+}
+
 func (f *LevelFlag) Get() any {
 	if f == nil {
 		return nil
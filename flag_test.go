@@ -3,6 +3,8 @@
 package logger
 
 import (
+	"flag"
+	"io"
 	"strings"
 	"testing"
 )
@@ -19,3 +21,30 @@ func TestLevelFlagString(t *testing.T) {
 		}
 	}
 }
+
+func TestLevelVar(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	level := LevelVar(fs, "loglevel", LevelInfo, "Minimum loglevel")
+	other := LevelVar(fs, "other", LevelError, "Unset loglevel")
+	if err := fs.Parse([]string{"-loglevel", "warn"}); err != nil {
+		t.Fatal(err)
+	}
+	if *level != LevelWarning || *other != LevelError {
+		t.Errorf("Expected %s and %s. Got %s and %s", LevelWarning, LevelError, *level, *other)
+	}
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse([]string{"-loglevel", "loud"}); err == nil {
+		t.Error("Expected an error for an invalid loglevel")
+	}
+}
+
+func TestLevelValue(t *testing.T) {
+	var level Level
+	v := LevelValue(&level, LevelNotice)
+	if level != LevelNotice || v.String() != "Notice" || v.Type() != "level" {
+		t.Errorf("Unexpected value %s of type %s", v, v.Type())
+	}
+	if err := v.Set("debug"); err != nil || level != LevelDebug {
+		t.Errorf("Expected %s. Got %s, %v", LevelDebug, level, err)
+	}
+}
@@ -0,0 +1,73 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// flightRecorder is a ring buffer holding the last records that were less severe than the Logger's loglevel.
+type flightRecorder struct {
+	recs      []Record
+	next      int   // Index of the oldest record once recs is full.
+	threshold Level // Least severe loglevel that replays the records.
+}
+
+// SetFlightRecorder makes the Logger keep the last size records that are less severe than its loglevel in memory
+// instead of discarding them. Once a record as severe as threshold or more severe is written, the kept records are
+// written before it, which shows what led to a failure without writing debug records all the time. Kept records
+// are neither sampled by a Sampler nor deduplicated. Passing 0 as size disables the recorder and discards the
+// kept records. A negative size or an invalid loglevel will cause a panic.
+func (l *Logger) SetFlightRecorder(size int, threshold Level) {
+	if size < 0 {
+		panic("Programming error: (l *Logger) SetFlightRecorder(): Passed negative size")
+	}
+	assertLoglevel(threshold)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if size == 0 {
+		l.recorder = nil
+		l.recording.Store(false)
+		return
+	}
+	l.recorder = &flightRecorder{recs: make([]Record, 0, size), threshold: threshold}
+	l.recording.Store(true)
+}
+
+// FlightRecords returns the records kept by the flight recorder, oldest first, see SetFlightRecorder.
+func (l *Logger) FlightRecords() []Record {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.recorder == nil {
+		return nil
+	}
+	return l.recorder.records()
+}
+
+// add keeps a copy of rec, replacing the oldest record if the recorder is full. rec must have been refined,
+// see (l *Logger) refine, the kept records are returned and replayed as they are.
+func (f *flightRecorder) add(rec *Record) {
+	kept := *rec
+	kept.Fields = append([]Field(nil), rec.Fields...)
+	if len(f.recs) < cap(f.recs) {
+		f.recs = append(f.recs, kept)
+		return
+	}
+	f.recs[f.next] = kept
+	f.next = (f.next + 1) % len(f.recs)
+}
+
+// records returns copies of the kept records, oldest first.
+func (f *flightRecorder) records() []Record {
+	recs := make([]Record, 0, len(f.recs))
+	recs = append(recs, f.recs[f.next:]...)
+	return append(recs, f.recs[:f.next]...)
+}
+
+// replay appends the records kept by the flight recorder to recs and empties the recorder.
+// The caller must hold the Logger's lock.
+func (l *Logger) replay(recs []*Record) []*Record {
+	f := l.recorder
+	kept := f.records()
+	for i := range kept {
+		recs = l.appendLimited(recs, &kept[i])
+	}
+	f.recs, f.next = f.recs[:0], 0
+	return recs
+}
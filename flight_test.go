@@ -0,0 +1,59 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelWarning, loglevelDelimiter)
+	l.SetFlightRecorder(2, LevelError)
+	l.Debug("dropped")
+	l.Info("first")
+	l.DebugKV("second", "key", "value")
+	l.Warning("warning")
+	if b.String() != "[Warning] - warning\n" {
+		t.Errorf("Expected %q. Got %q", "[Warning] - warning\n", b.String())
+	}
+	if recs := l.FlightRecords(); len(recs) != 2 || recs[0].Message != "first" || recs[1].Message != "second" {
+		t.Errorf("Unexpected flight records %+v", recs)
+	}
+	l.Error("failure")
+	expected := "[Warning] - warning\n[Info] - first\n[Debug] - second - key=value\n[Error] - failure\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	if recs := l.FlightRecords(); len(recs) != 0 {
+		t.Errorf("Expected the flight recorder to be empty. Got %+v", recs)
+	}
+	l.SetFlightRecorder(0, LevelError)
+	if l.Enabled(LevelDebug) || l.FlightRecords() != nil {
+		t.Error("Flight recorder was not disabled")
+	}
+}
+
+func TestFlightRecorderRefined(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelWarning, loglevelDelimiter)
+	l.SetFlightRecorder(2, LevelError)
+	l.AddRedactor(RedactBearerTokens)
+	l.DebugKV("login", Secret("password", "hunter2"), "auth", "bearer abc123")
+	recs := l.FlightRecords()
+	if len(recs) != 1 {
+		t.Fatalf("Expected 1 flight record. Got %+v", recs)
+	}
+	for _, f := range recs[0].Fields {
+		if s := fmt.Sprint(f.Value); strings.Contains(s, "hunter2") || strings.Contains(s, "abc123") {
+			t.Errorf("Flight record leaks %s=%s", f.Key, s)
+		}
+	}
+	l.Error("failure")
+	expected := `[Debug] - login - password=**** auth="bearer [REDACTED]"` + "\n[Error] - failure\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,197 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	FormatText Format = iota //Renders a record as a single line of text, its parts are separated by the Logger's delimiter.
+	FormatJSON               //Renders a record as a JSON object on a single line.
+	FormatCSV                //Renders a record as a line of comma separated values: time, level, prefix, message and fields.
+)
+
+// Represents the output format of a Logger.
+type Format int
+
+// jsonRecord is the layout of a record written in FormatJSON.
+type jsonRecord struct {
+	Level    string     `json:"level"`
+	Time     string     `json:"time"`
+	Hostname string     `json:"hostname,omitempty"`
+	PID      int        `json:"pid,omitempty"`
+	Caller   string     `json:"caller,omitempty"`
+	Prefix   string     `json:"prefix,omitempty"`
+	Message  string     `json:"message"`
+	Fields   jsonFields `json:"fields,omitempty"`
+	Stack    string     `json:"stack,omitempty"`
+}
+
+// Panics if the format does not exist.
+func assertFormat(format Format) {
+	if err := checkFormat(format); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Returns an error if the format does not exist.
+func checkFormat(format Format) error {
+	if format < FormatText || format > FormatCSV {
+		return fmt.Errorf("Output format %d is not defined", format)
+	}
+	return nil
+}
+
+// ParseFormat returns the Format whose string representation matches input, ignoring case.
+// On failure, it returns FormatText and an error.
+func ParseFormat(input string) (Format, error) {
+	switch strings.ToLower(input) {
+	case "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	}
+	return FormatText, fmt.Errorf("Input sequence %q cannot be associated with a defined output format", input)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the string representation of the Format,
+// or an error if the Format is not defined.
+func (f Format) MarshalText() ([]byte, error) {
+	if err := checkFormat(f); err != nil {
+		return nil, err
+	}
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all input ParseFormat accepts.
+func (f *Format) UnmarshalText(text []byte) error {
+	format, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = format
+	return nil
+}
+
+// String returns the string representation of a Format. If the Format is
+// not defined, String returns "Undefined".
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "Text"
+	case FormatJSON:
+		return "JSON"
+	case FormatCSV:
+		return "CSV"
+	}
+	return "Undefined"
+}
+
+// write passes rec to the Logger's sinks, then renders rec in the Logger's output format into a pooled buffer
+// and writes it to each of the Logger's outputs with a single call. The caller must hold the Logger's lock.
+func (l *Logger) write(rec *Record) (n int, err error) {
+	sinkErr := l.writeSinks(rec)
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf, err = l.encode(*buf, rec, l.format)
+	if err != nil {
+		l.handleError(err)
+		return 0, err
+	}
+	l.statsMu.Lock()
+	if l.records == nil {
+		l.records = make(map[Level]uint64)
+	}
+	l.records[rec.Level]++
+	l.statsMu.Unlock()
+	if n, err = l.writeOutputs(rec, *buf); err != nil {
+		return n, err
+	}
+	return n, sinkErr
+}
+
+// encode appends rec rendered in format to b.
+func (l *Logger) encode(b []byte, rec *Record, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return l.encodeJSON(b, rec)
+	case FormatCSV:
+		return l.encodeCSV(b, rec), nil
+	}
+	return l.encodeText(b, rec), nil
+}
+
+// encodePlain appends rec rendered in format without colors to b. The caller must hold the Logger's lock.
+func (l *Logger) encodePlain(b []byte, rec *Record, format Format) ([]byte, error) {
+	colorize := l.colorize
+	l.colorize = false
+	defer func() { l.colorize = colorize }()
+	return l.encode(b, rec, format)
+}
+
+// encodeText appends rec rendered in FormatText to b. The segments of the Logger's layout are separated by the delimiter,
+// unless the Logger has a template.
+// A stack trace follows the record on separate lines.
+func (l *Logger) encodeText(b []byte, rec *Record) []byte {
+	if l.template != nil {
+		return l.encodeTemplate(b, rec)
+	}
+	first := true
+	for _, seg := range l.textLayout() {
+		start := len(b)
+		if !first {
+			b = append(b, l.delimiter...)
+		}
+		segStart := len(b)
+		var ok bool
+		if b, ok = l.appendSegment(b, seg, rec); !ok {
+			b = b[:start]
+			continue
+		}
+		if l.escapeDelimiter {
+			b = escapeSegment(b, segStart, l.delimiter)
+		}
+		first = false
+	}
+	if len(rec.Stack) > 0 {
+		b = append(b, '\n')
+		b = append(b, rec.Stack...)
+	}
+	return append(b, '\n')
+}
+
+// encodeJSON appends rec rendered in FormatJSON to b. The timestamp is rendered according to the Logger's
+// time style, if the style uses the time format and none is set, time.RFC3339Nano is used.
+func (l *Logger) encodeJSON(b []byte, rec *Record) ([]byte, error) {
+	ts, _ := l.appendTime(nil, rec.Time, time.RFC3339Nano)
+	jrec := &jsonRecord{
+		Level:   rec.Level.String(),
+		Time:    string(ts),
+		Prefix:  rec.Prefix,
+		Message: rec.Message,
+		Fields:  rec.Fields,
+		Stack:   rec.Stack,
+	}
+	if l.reportCaller && rec.HasCaller() {
+		jrec.Caller = callerString(rec)
+	}
+	if l.includeHostname {
+		jrec.Hostname = hostname()
+	}
+	if l.includePID {
+		jrec.PID = os.Getpid()
+	}
+	enc, err := json.Marshal(jrec)
+	if err != nil {
+		return b, err
+	}
+	b = append(b, enc...)
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,53 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatJSON(t *testing.T) {
+	const msg = "This is a \"quoted\" sample log record"
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetFormat(FormatJSON)
+	if l.Format() != FormatJSON {
+		t.Fatalf("Expected format %s, got format %s", FormatJSON, l.Format())
+	}
+	l.Warningf("%s\n", msg)
+	if !strings.HasSuffix(b.String(), "}\n") {
+		t.Errorf("Record %q does not end with a single newline", b.String())
+	}
+	rec := new(jsonRecord)
+	if err := json.Unmarshal([]byte(b.String()), rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Level != LevelWarning.String() {
+		t.Errorf("Expected level %q, got level %q", LevelWarning.String(), rec.Level)
+	}
+	if rec.Message != msg {
+		t.Errorf("Expected message %q, got message %q", msg, rec.Message)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, rec.Time); err != nil {
+		t.Errorf("Timestamp %q is not formatted as RFC3339: %s", rec.Time, err)
+	}
+}
+
+func TestFormatJSONTimeFormat(t *testing.T) {
+	const layout = "2006-01-02"
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetFormat(FormatJSON)
+	l.SetTimeFormat(layout)
+	l.Info("message")
+	rec := new(jsonRecord)
+	if err := json.Unmarshal([]byte(b.String()), rec); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := time.Parse(layout, rec.Time); err != nil {
+		t.Errorf("Timestamp %q does not match the layout %q: %s", rec.Time, layout, err)
+	}
+}
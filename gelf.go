@@ -0,0 +1,198 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+)
+
+// GELF protocol constants.
+const (
+	gelfChunkHeaderSize   = 12   // Magic bytes, message ID, sequence number and sequence count.
+	gelfMaxChunks         = 128  // Maximum number of chunks per message.
+	defaultGELFChunkSize  = 8192 // Default maximum size of a UDP datagram.
+	minGELFChunkSize      = gelfChunkHeaderSize + 1
+	gelfChunkMagicByte1   = 0x1e
+	gelfChunkMagicByte2   = 0x0f
+	gelfReservedFieldName = "_id"
+)
+
+// GELFOptions configures a GELF sink.
+type GELFOptions struct {
+	Host      string     // Value of the GELF host field, defaults to the hostname.
+	ChunkSize int        // Maximum size of a UDP datagram, larger messages are chunked. Defaults to 8192.
+	Compress  bool       // Compress UDP messages with gzip.
+	Net       NetOptions // Options of the underlying NetWriter.
+}
+
+// GELF is a Sink that sends records to Graylog in the Graylog Extended Log Format 1.1. Over UDP, messages
+// that exceed the chunk size are split into chunks, over TCP messages are terminated by a null byte.
+// The level is sent as syslog severity, the prefix, the caller and the record's fields are sent as
+// additional fields. The transport is a NetWriter, so records are buffered and connections are re-established.
+type GELF struct {
+	w         *NetWriter
+	udp       bool
+	host      string
+	chunkSize int
+	compress  bool
+}
+
+// NewGELF returns a GELF sink that sends records to addr on the named network, which is either "udp" or "tcp".
+func NewGELF(network, addr string, opts GELFOptions) (*GELF, error) {
+	udp := strings.HasPrefix(network, "udp")
+	if !udp && !strings.HasPrefix(network, "tcp") {
+		return nil, errors.New("GELF is only supported over udp and tcp")
+	}
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = defaultGELFChunkSize
+	}
+	if opts.ChunkSize < minGELFChunkSize {
+		return nil, errors.New("GELF chunk size is too small")
+	}
+	if len(opts.Host) < 1 {
+		opts.Host = hostname()
+	}
+	w, err := NewNetWriter(network, addr, opts.Net)
+	if err != nil {
+		return nil, err
+	}
+	return &GELF{w: w, udp: udp, host: opts.Host, chunkSize: opts.ChunkSize, compress: opts.Compress}, nil
+}
+
+// Close sends the buffered messages and closes the connection.
+func (g *GELF) Close() error {
+	return g.w.Close()
+}
+
+// Dropped returns the number of messages or chunks that have been dropped by the underlying NetWriter.
+func (g *GELF) Dropped() uint64 {
+	return g.w.Dropped()
+}
+
+// WriteRecord sends rec to Graylog.
+func (g *GELF) WriteRecord(rec *Record) error {
+	msg, err := gelfMessage(g.host, rec)
+	if err != nil {
+		return err
+	}
+	if !g.udp {
+		_, err := g.w.Write(append(msg, 0))
+		return err
+	}
+	if g.compress {
+		if msg, err = gzipBytes(msg); err != nil {
+			return err
+		}
+	}
+	chunks, err := gelfChunks(msg, g.chunkSize)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if _, err := g.w.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gelfMessage renders rec as GELF 1.1 message. The first line of the message is sent as short_message,
+// a multi-line message or a message with a stack trace is additionally sent completely as full_message.
+func gelfMessage(host string, rec *Record) ([]byte, error) {
+	msg := map[string]any{
+		"version":   "1.1",
+		"host":      host,
+		"timestamp": math.Round(float64(rec.Time.UnixNano())/1e6) / 1e3,
+		"level":     rec.Level.SyslogSeverity(),
+	}
+	if short, _, multiline := strings.Cut(rec.Message, "\n"); multiline {
+		msg["short_message"] = short
+		msg["full_message"] = rec.Message
+	} else {
+		msg["short_message"] = rec.Message
+	}
+	if len(rec.Stack) > 0 {
+		msg["full_message"] = rec.Message + "\n" + rec.Stack
+	}
+	if len(rec.Prefix) > 0 {
+		msg["_prefix"] = rec.Prefix
+	}
+	if rec.HasCaller() {
+		msg["_file"] = rec.Caller.File
+		msg["_line"] = rec.Caller.Line
+	}
+	for _, f := range rec.Fields {
+		msg[gelfFieldName(f.Key)] = gelfFieldValue(f.Value)
+	}
+	return json.Marshal(msg)
+}
+
+// gelfFieldName converts key into the name of an additional GELF field. Additional fields start with an
+// underscore and consist of letters, digits, underscores, dashes and dots. The reserved name "_id" is renamed to "_id_".
+func gelfFieldName(key string) string {
+	name := []byte("_" + key)
+	for i, c := range name {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '_' && c != '-' && c != '.' {
+			name[i] = '_'
+		}
+	}
+	if string(name) == gelfReservedFieldName {
+		return gelfReservedFieldName + "_"
+	}
+	return string(name)
+}
+
+// gelfFieldValue returns v if it is a number or a string, otherwise its text representation,
+// as GELF only supports these types for additional fields.
+func gelfFieldValue(v any) any {
+	switch v := v.(type) {
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	}
+	return fieldValueString(v)
+}
+
+// gelfChunks splits msg into GELF chunks that are at most size bytes large. If msg fits, it is returned unchanged.
+func gelfChunks(msg []byte, size int) ([][]byte, error) {
+	if len(msg) <= size {
+		return [][]byte{msg}, nil
+	}
+	payload := size - gelfChunkHeaderSize
+	count := (len(msg) + payload - 1) / payload
+	if count > gelfMaxChunks {
+		return nil, errors.New("GELF message exceeds the maximum number of chunks")
+	}
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := min((i+1)*payload, len(msg))
+		chunk := make([]byte, 0, gelfChunkHeaderSize+end-i*payload)
+		chunk = append(chunk, gelfChunkMagicByte1, gelfChunkMagicByte2)
+		chunk = append(chunk, id[:]...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunks = append(chunks, append(chunk, msg[i*payload:end]...))
+	}
+	return chunks, nil
+}
+
+// gzipBytes compresses b with gzip.
+func gzipBytes(b []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,96 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestGELFMessage(t *testing.T) {
+	rec := &Record{
+		Level:   LevelWarning,
+		Time:    time.Unix(1678806566, 123000000),
+		Prefix:  "db",
+		Message: "slow query\nSELECT * FROM users",
+		Fields:  []Field{{Key: "id", Value: 7}, {Key: "table name", Value: "users"}, {Key: "ok", Value: true}},
+	}
+	b, err := gelfMessage("web1", rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := make(map[string]any)
+	if err := json.Unmarshal(b, &msg); err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]any{
+		"version":       "1.1",
+		"host":          "web1",
+		"timestamp":     1678806566.123,
+		"level":         float64(4),
+		"short_message": "slow query",
+		"full_message":  "slow query\nSELECT * FROM users",
+		"_prefix":       "db",
+		"_id_":          float64(7),
+		"_table_name":   "users",
+		"_ok":           "true",
+	}
+	if len(msg) != len(expect) {
+		t.Errorf("Expected %d members, got %s", len(expect), b)
+	}
+	for k, v := range expect {
+		if msg[k] != v {
+			t.Errorf("Expected member %q to be %v, got %v", k, v, msg[k])
+		}
+	}
+}
+
+func TestGELFChunkedUDP(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	g, err := NewGELF("udp", server.LocalAddr().String(), GELFOptions{Host: "test", ChunkSize: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	l := New(io.Discard, LevelDebug, loglevelDelimiter)
+	l.AddSink(g, LevelDebug)
+	l.Info(string(bytes.Repeat([]byte("x"), 300)))
+	var chunks [][]byte
+	server.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		buf := make([]byte, 200)
+		n, _, err := server.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n > 100 || buf[0] != gelfChunkMagicByte1 || buf[1] != gelfChunkMagicByte2 {
+			t.Fatalf("Received malformed chunk %q", buf[:n])
+		}
+		chunks = append(chunks, buf[:n])
+		if int(buf[11]) == len(chunks) {
+			break
+		}
+	}
+	msg := new(bytes.Buffer)
+	for i, chunk := range chunks {
+		if int(chunk[10]) != i || !bytes.Equal(chunk[2:10], chunks[0][2:10]) {
+			t.Fatalf("Chunk %d has an invalid header", i)
+		}
+		msg.Write(chunk[gelfChunkHeaderSize:])
+	}
+	decoded := make(map[string]any)
+	if err := json.Unmarshal(msg.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["host"] != "test" || len(decoded["short_message"].(string)) != 300 {
+		t.Errorf("Reassembled message is invalid: %s", msg.Bytes())
+	}
+}
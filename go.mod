@@ -1,3 +1,3 @@
 module github.com/jwdev42/logger
 
-go 1.20
+go 1.21
@@ -0,0 +1,108 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+const (
+	GroupRecords GroupStyle = iota //Begin and end of a group are marked by records of LevelInfo.
+	GroupGitHub                    //The outermost group is marked by the workflow commands "::group::" and "::endgroup::" of GitHub Actions, which make it collapsible.
+	GroupIndent                    //Groups are not marked, their records are only indented.
+)
+
+// groupIndent is prepended to the message of a record in FormatText once for every group it belongs to.
+const groupIndent = "  "
+
+// Represents the way a Logger marks the groups started by Group.
+type GroupStyle int
+
+// assertGroupStyle panics if style is not a defined GroupStyle.
+func assertGroupStyle(style GroupStyle) {
+	if err := checkGroupStyle(style); err != nil {
+		panic(fmt.Errorf("Programming error: %w", err))
+	}
+}
+
+// checkGroupStyle returns an error if style is not a defined GroupStyle.
+func checkGroupStyle(style GroupStyle) error {
+	if style < GroupRecords || style > GroupIndent {
+		return fmt.Errorf("Undefined group style %d", style)
+	}
+	return nil
+}
+
+// String returns the name of the GroupStyle.
+func (r GroupStyle) String() string {
+	switch r {
+	case GroupRecords:
+		return "Records"
+	case GroupGitHub:
+		return "GitHub"
+	case GroupIndent:
+		return "Indent"
+	}
+	return "Undefined"
+}
+
+// GroupStyle returns the way the Logger marks groups.
+func (l *Logger) GroupStyle() GroupStyle {
+	defer l.runlock(l.rlock())
+	return l.groupStyle
+}
+
+// SetGroupStyle sets the way the Logger marks the groups started by Group, the default is GroupRecords.
+// Setting an undefined GroupStyle will cause a panic.
+func (l *Logger) SetGroupStyle(style GroupStyle) {
+	assertGroupStyle(style)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.groupStyle = style
+}
+
+// Group starts a section of related records, like the steps of a build, and returns the function that ends it.
+// Records sent until the group ends are indented in FormatText, groups can be nested. Begin and end of the group
+// are marked according to the Logger's GroupStyle. The group belongs to all Loggers derived from the same Logger.
+// Calling end more than once has no effect:
+//
+//	end := l.Group("test")
+//	defer end()
+func (l *Logger) Group(name string) (end func()) {
+	l.mu.Lock()
+	style := l.groupStyle
+	l.mu.Unlock()
+	outermost := l.groups.Load() == 0
+	switch {
+	case style == GroupRecords:
+		l.PrintString(LevelInfo, "Begin "+name)
+	case style == GroupGitHub && outermost:
+		l.writeCommand("::group::" + name + "\n")
+	}
+	l.groups.Add(1)
+	var ended atomic.Bool
+	return func() {
+		if ended.Swap(true) {
+			return
+		}
+		l.groups.Add(-1)
+		switch {
+		case style == GroupRecords:
+			l.PrintString(LevelInfo, "End "+name)
+		case style == GroupGitHub && outermost:
+			l.writeCommand("::endgroup::\n")
+		}
+	}
+}
+
+// writeCommand writes cmd to the Logger's writer as is, after the pending records of an asynchronous Logger.
+func (l *Logger) writeCommand(cmd string) {
+	if l.discard || l.closed.Load() {
+		return
+	}
+	l.Flush()
+	l.lock()
+	defer l.unlock()
+	l.writeTo(mainDestination, l.out, []byte(cmd))
+}
@@ -0,0 +1,48 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	end := l.Group("build")
+	l.Info("compiling")
+	endInner := l.WithPrefix("test").Group("test")
+	l.WithPrefix("test").Info("running")
+	endInner()
+	end()
+	end()
+	l.Info("done")
+	expected := "[Info] - Begin build\n" +
+		"[Info] -   compiling\n" +
+		"[Info] -   test: Begin test\n" +
+		"[Info] -     test: running\n" +
+		"[Info] -   test: End test\n" +
+		"[Info] - End build\n" +
+		"[Info] - done\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestGroupGitHub(t *testing.T) {
+	b := new(syncBuilder)
+	l := NewAsync(b, LevelInfo, loglevelDelimiter, 4)
+	l.SetGroupStyle(GroupGitHub)
+	end := l.Group("build")
+	l.Info("compiling")
+	endInner := l.Group("test")
+	l.Info("running")
+	endInner()
+	end()
+	l.Close()
+	expected := "::group::build\n[Info] -   compiling\n[Info] -     running\n::endgroup::\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
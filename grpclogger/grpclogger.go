@@ -0,0 +1,206 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+// The package grpclogger provides gRPC interceptors that log every RPC through a logger.Logger
+// and an adapter that lets gRPC's internal logging write to a logger.Logger.
+package grpclogger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jwdev42/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// Keys of the fields attached to the records of an RPC.
+const (
+	MethodKey   = "grpc.method"   //The full name of the RPC's method, e.g. "/package.Service/Method".
+	CodeKey     = "grpc.code"     //The RPC's status code, e.g. "NotFound".
+	DurationKey = "grpc.duration" //The duration of the RPC.
+)
+
+// CodeLevel returns the loglevel an RPC that finished with code is logged with. OK is logged with LevelInfo,
+// codes caused by the client like NotFound or InvalidArgument with LevelWarning, all others with LevelError.
+func CodeLevel(code codes.Code) logger.Level {
+	switch code {
+	case codes.OK:
+		return logger.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.Unauthenticated, codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return logger.LevelWarning
+	}
+	return logger.LevelError
+}
+
+// UnaryServerInterceptor returns an interceptor that logs every unary RPC served by a gRPC server.
+func UnaryServerInterceptor(l *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRPC(l, "finished unary call", info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that logs every streaming RPC served by a gRPC server.
+func StreamServerInterceptor(l *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logRPC(l, "finished streaming call", info.FullMethod, start, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns an interceptor that logs every unary RPC a gRPC client invokes.
+func UnaryClientInterceptor(l *logger.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		logRPC(l, "finished unary call", method, start, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns an interceptor that logs every streaming RPC a gRPC client invokes.
+// The RPC is logged once the stream could not be created or receiving from the stream fails,
+// which includes the regular end of the stream. An RPC whose server sends a single response,
+// like a client-streaming RPC, is logged once that response has been received.
+func StreamClientInterceptor(l *logger.Logger) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			logRPC(l, "finished streaming call", method, start, err)
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, l: l, method: method, start: start, serverStreams: desc.ServerStreams}, nil
+	}
+}
+
+// clientStream is a grpc.ClientStream that logs the RPC when the stream ends.
+type clientStream struct {
+	grpc.ClientStream
+	l             *logger.Logger
+	method        string
+	start         time.Time
+	serverStreams bool // The server sends a stream of responses instead of a single one.
+	done          bool
+}
+
+// RecvMsg receives a message from the stream and logs the RPC if the stream has ended.
+func (cs *clientStream) RecvMsg(m any) error {
+	err := cs.ClientStream.RecvMsg(m)
+	if cs.done || err == nil && cs.serverStreams {
+		return err
+	}
+	cs.done = true
+	if errors.Is(err, io.EOF) {
+		logRPC(cs.l, "finished streaming call", cs.method, cs.start, nil)
+	} else {
+		logRPC(cs.l, "finished streaming call", cs.method, cs.start, err)
+	}
+	return err
+}
+
+// logRPC sends a record describing an RPC that finished with err to l.
+func logRPC(l *logger.Logger, msg, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := CodeLevel(code)
+	if !l.Enabled(level) {
+		return
+	}
+	kv := []any{MethodKey, method, CodeKey, code.String(), DurationKey, time.Since(start)}
+	if err != nil {
+		kv = append(kv, logger.Err(err))
+	}
+	l.PrintKV(level, msg, kv...)
+}
+
+// LoggerV2 implements grpclog.LoggerV2, it writes gRPC's internal log messages to a Logger. gRPC's info messages
+// are sent with LevelDebug as they are mostly relevant while debugging connection problems, warnings are sent with
+// LevelWarning, errors with LevelError. Fatal messages are sent with LevelPanic, then the program exits like Die does.
+type LoggerV2 struct {
+	l         *logger.Logger
+	verbosity int
+}
+
+// Assures that *LoggerV2 implements grpclog.LoggerV2.
+var _ grpclog.LoggerV2 = (*LoggerV2)(nil)
+
+// NewLoggerV2 returns a LoggerV2 that writes to l. verbosity is the highest verbosity level that V reports
+// as enabled. Pass the result to grpclog.SetLoggerV2 to install it.
+func NewLoggerV2(l *logger.Logger, verbosity int) *LoggerV2 {
+	return &LoggerV2{l: l, verbosity: verbosity}
+}
+
+// Error logs args with LevelError, they are formatted like fmt.Sprint does.
+func (g *LoggerV2) Error(args ...any) {
+	g.l.Println(logger.LevelError, args...)
+}
+
+// Errorf logs a formatted message with LevelError.
+func (g *LoggerV2) Errorf(format string, args ...any) {
+	g.l.Printf(logger.LevelError, format, args...)
+}
+
+// Errorln logs args with LevelError, they are formatted like fmt.Sprintln does.
+func (g *LoggerV2) Errorln(args ...any) {
+	g.l.Println(logger.LevelError, fmt.Sprintln(args...))
+}
+
+// Fatal logs args with LevelPanic, then exits.
+func (g *LoggerV2) Fatal(args ...any) {
+	g.l.Die(args...)
+}
+
+// Fatalf logs a formatted message with LevelPanic, then exits.
+func (g *LoggerV2) Fatalf(format string, args ...any) {
+	g.l.Dief(format, args...)
+}
+
+// Fatalln logs args formatted like fmt.Sprintln does with LevelPanic, then exits.
+func (g *LoggerV2) Fatalln(args ...any) {
+	g.l.Die(fmt.Sprintln(args...))
+}
+
+// Info logs args with LevelDebug, they are formatted like fmt.Sprint does.
+func (g *LoggerV2) Info(args ...any) {
+	g.l.Println(logger.LevelDebug, args...)
+}
+
+// Infof logs a formatted message with LevelDebug.
+func (g *LoggerV2) Infof(format string, args ...any) {
+	g.l.Printf(logger.LevelDebug, format, args...)
+}
+
+// Infoln logs args with LevelDebug, they are formatted like fmt.Sprintln does.
+func (g *LoggerV2) Infoln(args ...any) {
+	g.l.Println(logger.LevelDebug, fmt.Sprintln(args...))
+}
+
+// V returns true if level is at most the LoggerV2's verbosity.
+func (g *LoggerV2) V(level int) bool {
+	return level <= g.verbosity
+}
+
+// Warning logs args with LevelWarning, they are formatted like fmt.Sprint does.
+func (g *LoggerV2) Warning(args ...any) {
+	g.l.Println(logger.LevelWarning, args...)
+}
+
+// Warningf logs a formatted message with LevelWarning.
+func (g *LoggerV2) Warningf(format string, args ...any) {
+	g.l.Printf(logger.LevelWarning, format, args...)
+}
+
+// Warningln logs args with LevelWarning, they are formatted like fmt.Sprintln does.
+func (g *LoggerV2) Warningln(args ...any) {
+	g.l.Println(logger.LevelWarning, fmt.Sprintln(args...))
+}
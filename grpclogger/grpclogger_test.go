@@ -0,0 +1,143 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package grpclogger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jwdev42/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	b := new(strings.Builder)
+	l := logger.New(b, logger.LevelInfo, " - ")
+	intercept := UnaryServerInterceptor(l)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	intercept(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	intercept(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "no such item")
+	})
+	intercept(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.Internal, "database down")
+	})
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	expected := []string{
+		"[Info] - finished unary call - grpc.method=/test.Service/Get grpc.code=OK grpc.duration=",
+		"[Warning] - finished unary call - grpc.method=/test.Service/Get grpc.code=NotFound grpc.duration=",
+		"[Error] - finished unary call - grpc.method=/test.Service/Get grpc.code=Internal grpc.duration=",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d records. Got %q", len(expected), b.String())
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("Expected %q to start with %q", lines[i], prefix)
+		}
+	}
+	if !strings.HasSuffix(lines[2], ` error="rpc error: code = Internal desc = database down"`) {
+		t.Errorf("Expected the error to be attached. Got %q", lines[2])
+	}
+}
+
+// fakeClientStream is a grpc.ClientStream whose RecvMsg returns the errors in recv one after another.
+type fakeClientStream struct {
+	grpc.ClientStream
+	recv []error
+}
+
+func (s *fakeClientStream) RecvMsg(m any) error {
+	err := s.recv[0]
+	s.recv = s.recv[1:]
+	return err
+}
+
+// checkRecords fails the test if the records in b do not start with the prefixes in expected.
+func checkRecords(t *testing.T, b *strings.Builder, expected []string) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d records. Got %q", len(expected), b.String())
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("Expected %q to start with %q", lines[i], prefix)
+		}
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	b := new(strings.Builder)
+	l := logger.New(b, logger.LevelInfo, " - ")
+	intercept := StreamServerInterceptor(l)
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch"}
+	intercept(nil, nil, info, func(srv any, ss grpc.ServerStream) error {
+		return nil
+	})
+	intercept(nil, nil, info, func(srv any, ss grpc.ServerStream) error {
+		return status.Error(codes.Unavailable, "shutting down")
+	})
+	checkRecords(t, b, []string{
+		"[Info] - finished streaming call - grpc.method=/test.Service/Watch grpc.code=OK grpc.duration=",
+		"[Error] - finished streaming call - grpc.method=/test.Service/Watch grpc.code=Unavailable grpc.duration=",
+	})
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	b := new(strings.Builder)
+	l := logger.New(b, logger.LevelInfo, " - ")
+	intercept := StreamClientInterceptor(l)
+	stream := func(desc *grpc.StreamDesc, method string, recv ...error) {
+		cs, err := intercept(context.Background(), desc, nil, method, func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			if len(recv) < 1 {
+				return nil, status.Error(codes.Unavailable, "no connection")
+			}
+			return &fakeClientStream{recv: recv}, nil
+		})
+		if err != nil {
+			return
+		}
+		for range recv {
+			if cs.RecvMsg(nil) != nil {
+				return
+			}
+		}
+	}
+	// A client-streaming RPC ends with its single response, the caller does not receive again.
+	stream(&grpc.StreamDesc{ClientStreams: true}, "/test.Service/Upload", nil)
+	stream(&grpc.StreamDesc{ServerStreams: true}, "/test.Service/Watch", nil, nil, io.EOF)
+	stream(&grpc.StreamDesc{ServerStreams: true}, "/test.Service/Watch", nil, status.Error(codes.NotFound, "no such item"))
+	stream(&grpc.StreamDesc{ServerStreams: true}, "/test.Service/Watch")
+	stream(&grpc.StreamDesc{ServerStreams: true}, "/test.Service/Watch", errors.New("broken"))
+	checkRecords(t, b, []string{
+		"[Info] - finished streaming call - grpc.method=/test.Service/Upload grpc.code=OK grpc.duration=",
+		"[Info] - finished streaming call - grpc.method=/test.Service/Watch grpc.code=OK grpc.duration=",
+		"[Warning] - finished streaming call - grpc.method=/test.Service/Watch grpc.code=NotFound grpc.duration=",
+		"[Error] - finished streaming call - grpc.method=/test.Service/Watch grpc.code=Unavailable grpc.duration=",
+		"[Error] - finished streaming call - grpc.method=/test.Service/Watch grpc.code=Unknown grpc.duration=",
+	})
+}
+
+func TestLoggerV2(t *testing.T) {
+	b := new(strings.Builder)
+	l := logger.New(b, logger.LevelInfo, " - ")
+	g := NewLoggerV2(l, 1)
+	g.Info("filtered")
+	g.Warningln("subchannel", "down")
+	g.Errorf("code %d", 14)
+	expected := "[Warning] - subchannel down\n[Error] - code 14\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	if !g.V(1) || g.V(2) {
+		t.Error("Expected verbosity 1 to be enabled and 2 to be disabled")
+	}
+}
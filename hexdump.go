@@ -0,0 +1,26 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/hex"
+	"strconv"
+)
+
+// HexDump sends label and a canonical hex dump of data to the Logger, one line of offset, hex bytes and ASCII
+// characters per 16 bytes like "hexdump -C" prints them. The dump is only rendered if level is enabled:
+//
+//	l.HexDump(logger.LevelTrace, "handshake", packet)
+func (l *Logger) HexDump(level Level, label string, data []byte) (n int, err error) {
+	if !l.Enabled(level) {
+		return 0, nil
+	}
+	b := append([]byte(label), " ("...)
+	b = strconv.AppendInt(b, int64(len(data)), 10)
+	b = append(b, " bytes)"...)
+	if len(data) > 0 {
+		b = append(b, ":\n"...)
+		b = append(b, hex.Dump(data)...)
+	}
+	return l.Output(Record{Level: level, Message: string(b)})
+}
@@ -0,0 +1,23 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHexDump(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.HexDump(LevelDebug, "packet", []byte("GET / HTTP/1.1\r\nHost"))
+	l.HexDump(LevelDebug, "empty", nil)
+	l.HexDump(LevelTrace, "filtered", []byte{1})
+	expected := "[Debug] - packet (20 bytes):\n" +
+		"00000000  47 45 54 20 2f 20 48 54  54 50 2f 31 2e 31 0d 0a  |GET / HTTP/1.1..|\n" +
+		"00000010  48 6f 73 74                                       |Host|\n" +
+		"[Debug] - empty (0 bytes)\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,44 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// Hook is called for every record of the levels it is registered for before the record is written.
+// Hooks can mirror records to other systems, collect metrics or modify the record.
+type Hook interface {
+	Levels() []Level        // Levels returns the loglevels of the records the Hook wants to receive.
+	Fire(rec *Record) error // Fire is called with the record before it is written, changes to rec will be written.
+}
+
+// AddHook registers h for the levels returned by h.Levels(). Hooks are only fired for records that pass
+// the Logger's loglevel, they are fired in the order they were added. Records a Hook sends to the Logger that fired it
+// are written after the record that fired the Hook, see ErrReentrancy. Passing a Hook that returns an invalid loglevel
+// will cause a panic.
+func (l *Logger) AddHook(h Hook) {
+	if h == nil {
+		panic("Programming error: (l *Logger) AddHook(): Passed nil as hook")
+	}
+	levels := h.Levels()
+	for _, lvl := range levels {
+		assertLoglevel(lvl)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.hooks == nil {
+		l.hooks = make(map[Level][]Hook)
+	}
+	for _, lvl := range levels {
+		l.hooks[lvl] = append(l.hooks[lvl], h)
+	}
+}
+
+// fireHooks fires all hooks registered for the level of rec. All hooks are fired even if one of them fails,
+// the first error is returned. The caller must hold the Logger's lock.
+func (l *Logger) fireHooks(rec *Record) error {
+	var err error
+	for _, h := range l.hooks[rec.Level] {
+		if herr := h.Fire(rec); herr != nil && err == nil {
+			err = herr
+		}
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testHook struct {
+	fired int
+	err   error
+}
+
+func (h *testHook) Levels() []Level {
+	return []Level{LevelError, LevelWarning}
+}
+
+func (h *testHook) Fire(rec *Record) error {
+	h.fired++
+	rec.Message = strings.ToUpper(rec.Message)
+	return h.err
+}
+
+func TestHook(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	h := &testHook{}
+	l.AddHook(h)
+	l.Info("info")
+	l.Warning("warning")
+	l.Debug("debug")
+	h.err = errors.New("hook failed")
+	if _, err := l.Error("error"); err != h.err {
+		t.Errorf("Expected error %v, got %v", h.err, err)
+	}
+	if h.fired != 2 {
+		t.Errorf("Expected hook to be fired 2 times, got %d", h.fired)
+	}
+	expect := "[Info] - info\n[Warning] - WARNING\n[Error] - ERROR\n"
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
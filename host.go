@@ -0,0 +1,58 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"os"
+	"sync"
+)
+
+// hostname caches the result of os.Hostname.
+var hostname = sync.OnceValue(func() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return name
+})
+
+// IncludeHostname returns true if the Logger adds the hostname to its records.
+func (l *Logger) IncludeHostname() bool {
+	defer l.runlock(l.rlock())
+	return l.includeHostname
+}
+
+// IncludePID returns true if the Logger adds the process ID to its records.
+func (l *Logger) IncludePID() bool {
+	defer l.runlock(l.rlock())
+	return l.includePID
+}
+
+// SetIncludeHostname sets whether the Logger adds the name of the host the program runs on to its records.
+// In FormatText it is written as field "hostname" in front of the record's fields, in FormatJSON as member "hostname".
+func (l *Logger) SetIncludeHostname(include bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.includeHostname = include
+}
+
+// SetIncludePID sets whether the Logger adds the ID of the process to its records.
+// In FormatText it is written as field "pid" in front of the record's fields, in FormatJSON as member "pid".
+func (l *Logger) SetIncludePID(include bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.includePID = include
+}
+
+// processFields returns the hostname and process ID fields the Logger is configured to include.
+// The caller must hold the Logger's lock.
+func (l *Logger) processFields() []Field {
+	var fields []Field
+	if l.includeHostname {
+		fields = append(fields, Field{Key: "hostname", Value: hostname()})
+	}
+	if l.includePID {
+		fields = append(fields, Field{Key: "pid", Value: os.Getpid()})
+	}
+	return fields
+}
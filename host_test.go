@@ -0,0 +1,33 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIncludeHostnamePID(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetIncludeHostname(true)
+	l.SetIncludePID(true)
+	l.InfoKV("started", "port", 80)
+	expect := fmt.Sprintf("[Info] - started - %s pid=%d port=80\n", Field{Key: "hostname", Value: hostname()}, os.Getpid())
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+	b.Reset()
+	l.SetFormat(FormatJSON)
+	l.Info("started")
+	rec := new(jsonRecord)
+	if err := json.Unmarshal([]byte(b.String()), rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Hostname != hostname() || rec.PID != os.Getpid() {
+		t.Errorf("Expected hostname %q and pid %d, got %q", hostname(), os.Getpid(), b.String())
+	}
+}
@@ -0,0 +1,158 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"time"
+)
+
+// HTTPOptions configures the access log written by HTTPMiddleware.
+type HTTPOptions struct {
+	Level            Level  // Loglevel of requests answered with a status below 400, defaults to LevelInfo.
+	ClientErrorLevel Level  // Loglevel of requests answered with a 4xx status, defaults to LevelWarning.
+	ServerErrorLevel Level  // Loglevel of requests answered with a 5xx status, defaults to LevelError.
+	Message          string // Message of the records, defaults to "HTTP request".
+}
+
+// statusRecorder is an http.ResponseWriter that records the status and the size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+// flushRecorder is a statusRecorder for an http.ResponseWriter that implements http.Flusher.
+type flushRecorder struct {
+	*statusRecorder
+}
+
+// hijackRecorder is a statusRecorder for an http.ResponseWriter that implements http.Hijacker.
+type hijackRecorder struct {
+	*statusRecorder
+}
+
+// flushHijackRecorder is a statusRecorder for an http.ResponseWriter that implements http.Flusher and http.Hijacker.
+type flushHijackRecorder struct {
+	*statusRecorder
+}
+
+// HTTPMiddleware returns a middleware that wraps an http.Handler, so it sends a record for every request to l:
+//
+//	http.ListenAndServe(":8080", logger.HTTPMiddleware(l, logger.HTTPOptions{})(mux))
+//
+// The record
+// carries the request's method and path and the response's status, the latency of the handler and the size of the
+// response body in bytes as fields "method", "path", "status", "latency" and "size". Its loglevel depends on
+// the status, see HTTPOptions. The handler can use http.Flusher and http.Hijacker if the server's
+// http.ResponseWriter implements them, a hijacked connection is logged with the status 101 Switching Protocols
+// unless the handler sent another one. Passing an invalid loglevel in opts will cause a panic.
+func HTTPMiddleware(l *Logger, opts HTTPOptions) func(next http.Handler) http.Handler {
+	setDefault(&opts.Level, LevelInfo)
+	setDefault(&opts.ClientErrorLevel, LevelWarning)
+	setDefault(&opts.ServerErrorLevel, LevelError)
+	setDefault(&opts.Message, "HTTP request")
+	for _, level := range []Level{opts.Level, opts.ClientErrorLevel, opts.ServerErrorLevel} {
+		assertLoglevel(level)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec.wrap(), r)
+			latency := time.Since(start)
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+			level := opts.Level
+			switch {
+			case rec.status >= 500:
+				level = opts.ServerErrorLevel
+			case rec.status >= 400:
+				level = opts.ClientErrorLevel
+			}
+			l.PrintKV(level, opts.Message, "method", r.Method, "path", r.URL.Path, "status", rec.status,
+				"latency", latency, "size", rec.size)
+		})
+	}
+}
+
+// wrap returns rec as an http.ResponseWriter that implements the same optional interfaces of
+// http.Flusher and http.Hijacker as the underlying one, so handlers can find them by a type assertion.
+func (rec *statusRecorder) wrap() http.ResponseWriter {
+	_, flusher := rec.ResponseWriter.(http.Flusher)
+	_, hijacker := rec.ResponseWriter.(http.Hijacker)
+	switch {
+	case flusher && hijacker:
+		return flushHijackRecorder{rec}
+	case flusher:
+		return flushRecorder{rec}
+	case hijacker:
+		return hijackRecorder{rec}
+	}
+	return rec
+}
+
+// flush sends the buffered response. A response without status is sent with 200 OK.
+func (rec *statusRecorder) flush() {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijack lets the caller take over the connection, see http.Hijacker.
+func (rec *statusRecorder) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := rec.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil && rec.status == 0 {
+		rec.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Flush implements http.Flusher.
+func (rec flushRecorder) Flush() {
+	rec.flush()
+}
+
+// Hijack implements http.Hijacker.
+func (rec hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return rec.hijack()
+}
+
+// Flush implements http.Flusher.
+func (rec flushHijackRecorder) Flush() {
+	rec.flush()
+}
+
+// Hijack implements http.Hijacker.
+func (rec flushHijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return rec.hijack()
+}
+
+// Unwrap returns the underlying http.ResponseWriter, so http.ResponseController can reach its optional interfaces.
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// Write writes b to the response and counts its size. A response without status is sent with 200 OK.
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(b)
+	rec.size += int64(n)
+	return n, err
+}
+
+// WriteHeader records the status and sends it. Only the first final status is recorded, like net/http only sends
+// the first one. Informational statuses like 103 Early Hints are sent, but not recorded.
+func (rec *statusRecorder) WriteHeader(status int) {
+	informational := status >= 100 && status < 200 && status != http.StatusSwitchingProtocols
+	if rec.status == 0 && !informational {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
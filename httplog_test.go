@@ -0,0 +1,94 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetLayout(SegmentLevel, SegmentMessage)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "failed", http.StatusBadGateway)
+	})
+	h := HTTPMiddleware(l, HTTPOptions{Message: "access"})(mux)
+	for _, path := range []string{"/ok", "/missing", "/fail"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	expected := "[Info] - access\n[Warning] - access\n[Error] - access\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	b.Reset()
+	l.SetLayout(SegmentFields)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/ok", nil))
+	if fields := b.String(); !strings.HasPrefix(fields, "method=POST path=/ok status=200 latency=") ||
+		!strings.HasSuffix(fields, " size=5\n") {
+		t.Errorf("Unexpected fields %q", fields)
+	}
+}
+
+// hijackableRecorder is an httptest.ResponseRecorder that can be hijacked.
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (w hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	server, client := net.Pipe()
+	client.Close()
+	return server, nil, nil
+}
+
+func TestHTTPMiddlewareInterfaces(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetLayout(SegmentFields)
+	var flusher, hijacker bool
+	h := HTTPMiddleware(l, HTTPOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, flusher = w.(http.Flusher)
+		_, hijacker = w.(http.Hijacker)
+		switch r.URL.Path {
+		case "/events":
+			w.WriteHeader(http.StatusEarlyHints)
+			w.(http.Flusher).Flush()
+		case "/socket":
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn.Close()
+		}
+	}))
+	tests := []struct {
+		w        http.ResponseWriter
+		path     string
+		flusher  bool
+		hijacker bool
+		status   string
+	}{
+		{httptest.NewRecorder(), "/events", true, false, "status=200"},
+		{hijackableRecorder{httptest.NewRecorder()}, "/socket", true, true, "status=101"},
+		{struct{ http.ResponseWriter }{httptest.NewRecorder()}, "/plain", false, false, "status=200"},
+	}
+	for _, test := range tests {
+		b.Reset()
+		h.ServeHTTP(test.w, httptest.NewRequest(http.MethodGet, test.path, nil))
+		if flusher != test.flusher || hijacker != test.hijacker {
+			t.Errorf("%s: Expected Flusher %t and Hijacker %t. Got %t and %t", test.path, test.flusher, test.hijacker, flusher, hijacker)
+		}
+		if !strings.Contains(b.String(), test.status) {
+			t.Errorf("%s: Expected %s. Got %q", test.path, test.status, b.String())
+		}
+	}
+}
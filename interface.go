@@ -0,0 +1,33 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// Interface is the set of print methods of a Logger. Libraries can accept an Interface instead of a *Logger,
+// so their callers can substitute their own implementation. *Logger implements Interface, including the Loggers
+// returned by Discard and by loggertest.NewCapture.
+type Interface interface {
+	Alert(v ...any) (n int, err error)
+	Alertf(format string, a ...any) (n int, err error)
+	Critical(v ...any) (n int, err error)
+	Criticalf(format string, a ...any) (n int, err error)
+	Debug(v ...any) (n int, err error)
+	Debugf(format string, a ...any) (n int, err error)
+	Enabled(level Level) bool
+	Error(v ...any) (n int, err error)
+	Errorf(format string, a ...any) (n int, err error)
+	Info(v ...any) (n int, err error)
+	Infof(format string, a ...any) (n int, err error)
+	Notice(v ...any) (n int, err error)
+	Noticef(format string, a ...any) (n int, err error)
+	Panic(v ...any) (n int, err error)
+	Panicf(format string, a ...any) (n int, err error)
+	Println(level Level, v ...any) (n int, err error)
+	Printf(level Level, format string, a ...any) (n int, err error)
+	Trace(v ...any) (n int, err error)
+	Tracef(format string, a ...any) (n int, err error)
+	Warning(v ...any) (n int, err error)
+	Warningf(format string, a ...any) (n int, err error)
+}
+
+// Assures that *Logger implements Interface.
+var _ Interface = (*Logger)(nil)
@@ -0,0 +1,72 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// journalSocket is the path of the socket journald receives native protocol messages on.
+const journalSocket = "/run/systemd/journal/socket"
+
+// journalMessage renders rec in the native protocol of journald. The message is sent as MESSAGE,
+// the level as PRIORITY, the prefix as PREFIX and the caller as CODE_FILE, CODE_LINE and CODE_FUNC.
+// The record's fields are sent with their keys converted to valid journal field names.
+func journalMessage(identifier string, rec *Record) []byte {
+	b := make([]byte, 0, 128+len(rec.Message))
+	b = appendJournalField(b, "MESSAGE", rec.Message)
+	b = appendJournalField(b, "PRIORITY", strconv.Itoa(rec.Level.SyslogSeverity()))
+	b = appendJournalField(b, "SYSLOG_IDENTIFIER", identifier)
+	if len(rec.Prefix) > 0 {
+		b = appendJournalField(b, "PREFIX", rec.Prefix)
+	}
+	if rec.HasCaller() {
+		b = appendJournalField(b, "CODE_FILE", rec.Caller.File)
+		b = appendJournalField(b, "CODE_LINE", strconv.Itoa(rec.Caller.Line))
+		b = appendJournalField(b, "CODE_FUNC", rec.Caller.Function)
+	}
+	for _, f := range rec.Fields {
+		b = appendJournalField(b, journalFieldName(f.Key), fieldValueString(f.Value))
+	}
+	return b
+}
+
+// appendJournalField appends a field in the native protocol of journald to b. Values containing a newline
+// are sent in the binary form, prefixed by their length as 64 bit little endian integer.
+func appendJournalField(b []byte, name, value string) []byte {
+	b = append(b, name...)
+	if strings.IndexByte(value, '\n') < 0 {
+		b = append(b, '=')
+		b = append(b, value...)
+		return append(b, '\n')
+	}
+	b = append(b, '\n')
+	b = binary.LittleEndian.AppendUint64(b, uint64(len(value)))
+	b = append(b, value...)
+	return append(b, '\n')
+}
+
+// journalFieldName converts key into a valid journal field name. Field names consist of upper case letters,
+// digits and underscores and must not start with an underscore or a digit.
+func journalFieldName(key string) string {
+	name := []byte(strings.ToUpper(key))
+	for i, c := range name {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			name[i] = '_'
+		}
+	}
+	key = strings.TrimLeft(string(name), "_")
+	if len(key) < 1 || (key[0] >= '0' && key[0] <= '9') {
+		key = "F_" + key
+	}
+	return key
+}
+
+// journalIdentifier returns the default SYSLOG_IDENTIFIER, the base name of the program.
+func journalIdentifier() string {
+	return filepath.Base(os.Args[0])
+}
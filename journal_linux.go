@@ -0,0 +1,73 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build linux
+
+package logger
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+)
+
+// Journal is a Sink that sends records to the systemd journal using journald's native protocol.
+// Journal is only available on Linux.
+type Journal struct {
+	conn       *net.UnixConn
+	identifier string
+}
+
+// NewJournal connects to journald. The records are sent with the base name of the program as SYSLOG_IDENTIFIER.
+func NewJournal() (*Journal, error) {
+	return newJournal(journalSocket)
+}
+
+// newJournal connects to a journald socket at path.
+func newJournal(path string) (*Journal, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{conn: conn, identifier: journalIdentifier()}, nil
+}
+
+// Close closes the connection to journald.
+func (j *Journal) Close() error {
+	return j.conn.Close()
+}
+
+// SetIdentifier sets the SYSLOG_IDENTIFIER the records are sent with.
+func (j *Journal) SetIdentifier(identifier string) {
+	j.identifier = identifier
+}
+
+// WriteRecord sends rec to journald. Records that are too large for a datagram are passed as file descriptor.
+func (j *Journal) WriteRecord(rec *Record) error {
+	msg := journalMessage(j.identifier, rec)
+	_, err := j.conn.Write(msg)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+	return j.writeFD(msg)
+}
+
+// writeFD writes msg to an unlinked temporary file and sends its file descriptor to journald.
+func (j *Journal) writeFD(msg []byte) error {
+	f, err := os.CreateTemp("/dev/shm", "journal.*")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return err
+	}
+	if _, err := f.Write(msg); err != nil {
+		return err
+	}
+	_, _, err = j.conn.WriteMsgUnix(nil, syscall.UnixRights(int(f.Fd())), nil)
+	return err
+}
@@ -0,0 +1,57 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build linux
+
+package logger
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournalFieldName(t *testing.T) {
+	for key, expect := range map[string]string{
+		"request_id": "REQUEST_ID",
+		"http.path":  "HTTP_PATH",
+		"_private":   "PRIVATE",
+		"2fa":        "F_2FA",
+		"":           "F_",
+	} {
+		if name := journalFieldName(key); name != expect {
+			t.Errorf("Expected field name %q for key %q, got %q", expect, key, name)
+		}
+	}
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "socket")
+	server, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	j, err := newJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	j.SetIdentifier("test")
+	l := New(io.Discard, LevelDebug, loglevelDelimiter)
+	l.AddSink(j, LevelWarning)
+	l.Info("not sent")
+	l.ErrorKV("line 1\nline 2", "user", "bob")
+	buf := make([]byte, 4096)
+	n, err := server.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "MESSAGE\n\x0d\x00\x00\x00\x00\x00\x00\x00line 1\nline 2\n" +
+		"PRIORITY=3\n" +
+		"SYSLOG_IDENTIFIER=test\n" +
+		"USER=bob\n"
+	if got := string(buf[:n]); got != expect {
+		t.Errorf("Expected %q. Got %q", expect, got)
+	}
+}
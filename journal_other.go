@@ -0,0 +1,33 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build !linux
+
+package logger
+
+import "errors"
+
+// Journal is a Sink that sends records to the systemd journal using journald's native protocol.
+// Journal is only available on Linux.
+type Journal struct {
+	identifier string
+}
+
+// NewJournal returns an error as journald is only available on Linux.
+func NewJournal() (*Journal, error) {
+	return nil, errors.New("journald is only available on Linux")
+}
+
+// Close does nothing.
+func (j *Journal) Close() error {
+	return nil
+}
+
+// SetIdentifier sets the SYSLOG_IDENTIFIER the records are sent with.
+func (j *Journal) SetIdentifier(identifier string) {
+	j.identifier = identifier
+}
+
+// WriteRecord returns an error as journald is only available on Linux.
+func (j *Journal) WriteRecord(rec *Record) error {
+	return errors.New("journald is only available on Linux")
+}
@@ -0,0 +1,213 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SegmentLevel    Segment = iota //The record's level tag, e.g. "[Info]".
+	SegmentTime                    //The record's timestamp, omitted if the Logger has no time format.
+	SegmentCaller                  //The record's caller, omitted if the Logger does not report callers.
+	SegmentMessage                 //The record's message, preceded by the Logger's prefix.
+	SegmentFields                  //The record's fields as key=value pairs, omitted if there are none.
+	SegmentHostname                //The name of the host the program runs on.
+)
+
+// defaultLayout is the layout used by a Logger that has no layout set.
+var defaultLayout = []Segment{SegmentLevel, SegmentTime, SegmentCaller, SegmentMessage, SegmentFields}
+
+// Represents a part of a record in FormatText.
+type Segment int
+
+// Panics if the segment does not exist.
+func assertSegment(seg Segment) {
+	if err := checkSegment(seg); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Returns an error if the segment does not exist.
+func checkSegment(seg Segment) error {
+	if seg < SegmentLevel || seg > SegmentHostname {
+		return fmt.Errorf("Layout segment %d is not defined", seg)
+	}
+	return nil
+}
+
+// String returns the string representation of a Segment. If the Segment is
+// not defined, String returns "Undefined".
+func (seg Segment) String() string {
+	switch seg {
+	case SegmentLevel:
+		return "Level"
+	case SegmentTime:
+		return "Time"
+	case SegmentCaller:
+		return "Caller"
+	case SegmentMessage:
+		return "Message"
+	case SegmentFields:
+		return "Fields"
+	case SegmentHostname:
+		return "Hostname"
+	}
+	return "Undefined"
+}
+
+// ParseSegment returns the Segment whose string representation matches input, ignoring case.
+// On failure, it returns SegmentLevel and an error.
+func ParseSegment(input string) (Segment, error) {
+	switch strings.ToLower(input) {
+	case "level":
+		return SegmentLevel, nil
+	case "time":
+		return SegmentTime, nil
+	case "caller":
+		return SegmentCaller, nil
+	case "message":
+		return SegmentMessage, nil
+	case "fields":
+		return SegmentFields, nil
+	case "hostname":
+		return SegmentHostname, nil
+	}
+	return SegmentLevel, fmt.Errorf("Input sequence %q cannot be associated with a defined layout segment", input)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the string representation of the Segment,
+// or an error if the Segment is not defined.
+func (seg Segment) MarshalText() ([]byte, error) {
+	if err := checkSegment(seg); err != nil {
+		return nil, err
+	}
+	return []byte(seg.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all input ParseSegment accepts.
+func (seg *Segment) UnmarshalText(text []byte) error {
+	s, err := ParseSegment(string(text))
+	if err != nil {
+		return err
+	}
+	*seg = s
+	return nil
+}
+
+// Layout returns the order of the segments the Logger renders its records with in FormatText.
+func (l *Logger) Layout() []Segment {
+	defer l.runlock(l.rlock())
+	return append([]Segment(nil), l.textLayout()...)
+}
+
+// SetLayout sets the segments and their order the Logger renders its records with in FormatText.
+// The segments are separated by the Logger's delimiter, segments without content are left out.
+// The default layout is SegmentLevel, SegmentTime, SegmentCaller, SegmentMessage, SegmentFields.
+// Calling SetLayout without segments restores the default layout. Passing an invalid segment will cause a panic.
+func (l *Logger) SetLayout(segments ...Segment) {
+	for _, seg := range segments {
+		assertSegment(seg)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(segments) < 1 {
+		l.layout = nil
+		return
+	}
+	l.layout = append([]Segment(nil), segments...)
+}
+
+// PadLevel returns true if the Logger pads its level tags to a uniform width.
+func (l *Logger) PadLevel() bool {
+	defer l.runlock(l.rlock())
+	return l.padLevel
+}
+
+// SetPadLevel enables or disables padding the level tags in FormatText with trailing spaces to the width of the
+// longest loglevel name in the Logger's level name style, e.g. "[Info]    " and "[Warning] ", so that the following segments are aligned.
+func (l *Logger) SetPadLevel(enable bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.padLevel = enable
+}
+
+// maxLevelNameWidth returns the number of characters of the longest loglevel name, including registered loglevels.
+func maxLevelNameWidth() int {
+	width := len(LevelCritical.String()) // Longest name of the predefined loglevels.
+	customLevels.RLock()
+	defer customLevels.RUnlock()
+	for _, name := range customLevels.names {
+		if w := utf8.RuneCountInString(name); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// textLayout returns the layout for FormatText. The caller must hold the Logger's lock.
+func (l *Logger) textLayout() []Segment {
+	if l.layout == nil {
+		return defaultLayout
+	}
+	return l.layout
+}
+
+// appendSegment appends the segment seg of rec to b. ok is false if the segment has no content.
+// The caller must hold the Logger's lock.
+func (l *Logger) appendSegment(b []byte, seg Segment, rec *Record) (_ []byte, ok bool) {
+	switch seg {
+	case SegmentLevel:
+		color := ""
+		if l.colorize {
+			color = levelColor(rec.Level)
+		}
+		b = append(b, color...)
+		name := l.levelName(rec.Level)
+		if d, ok := l.decorations[rec.Level]; ok {
+			b = append(b, d...)
+			b = append(b, ' ')
+		}
+		b = append(b, '[')
+		b = append(b, name...)
+		b = append(b, ']')
+		if len(color) > 0 {
+			b = append(b, ansiReset...)
+		}
+		if l.padLevel {
+			for pad := l.levelNameWidth() - utf8.RuneCountInString(name); pad > 0; pad-- {
+				b = append(b, ' ')
+			}
+		}
+	case SegmentTime:
+		return l.appendTime(b, rec.Time, "")
+	case SegmentCaller:
+		if !l.reportCaller || !rec.HasCaller() {
+			return b, false
+		}
+		b = append(b, callerString(rec)...)
+	case SegmentMessage:
+		for i := 0; i < rec.depth; i++ {
+			b = append(b, groupIndent...)
+		}
+		if len(rec.Prefix) > 0 {
+			b = append(b, rec.Prefix...)
+			b = append(b, ": "...)
+		}
+		b = append(b, rec.Message...)
+	case SegmentFields:
+		if len(rec.Fields) < 1 && !l.includeHostname && !l.includePID {
+			return b, false
+		}
+		b = appendTextFields(b, l.processFields()...)
+		if len(rec.Fields) > 0 && (l.includeHostname || l.includePID) {
+			b = append(b, ' ')
+		}
+		b = appendTextFields(b, rec.Fields...)
+	case SegmentHostname:
+		b = append(b, hostname()...)
+	}
+	return b, true
+}
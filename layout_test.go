@@ -0,0 +1,44 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLayout(t *testing.T) {
+	const layout = "2006-01-02"
+	ts := time.Date(2023, time.March, 14, 0, 0, 0, 0, time.UTC)
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetTimeFormat(layout)
+	l.SetLayout(SegmentTime, SegmentMessage, SegmentFields, SegmentHostname)
+	l.Output(Record{Level: LevelInfo, Time: ts, Message: "started", Fields: []Field{{Key: "pid", Value: 1}}})
+	l.SetTimeFormat("")
+	l.Output(Record{Level: LevelInfo, Time: ts, Message: "no time"})
+	l.SetLayout()
+	l.Output(Record{Level: LevelInfo, Time: ts, Message: "default"})
+	expect := fmt.Sprintf("2023-03-14 - started - pid=1 - %s\nno time - %s\n[Info] - default\n", hostname(), hostname())
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
+
+func TestPadLevel(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetPadLevel(true)
+	l.Info("one")
+	l.Critical("two")
+	l.SetColor(ColorAlways)
+	l.Warning("three")
+	expected := "[Info]     - one\n" +
+		"[Critical] - two\n" +
+		ansiYellow + "[Warning]" + ansiReset + "  - three\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
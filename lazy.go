@@ -0,0 +1,74 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Lazy wraps a function that computes an expensive argument of a print method or the value of a field.
+// The function is only called if the record is actually written, so costly serialization for debug
+// records does not run if LevelDebug is filtered:
+//
+//	l.Debugf("state: %s", logger.Lazy(func() any { return dumpState() }))
+//
+// Arguments of type func() string are evaluated lazily as well.
+type Lazy func() any
+
+// Format implements fmt.Formatter, it formats the result of the function according to verb.
+func (f Lazy) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, fmt.FormatString(s, verb), f())
+}
+
+// MarshalJSON implements json.Marshaler, it encodes the result of the function.
+func (f Lazy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f())
+}
+
+// resolveLazy returns v with all arguments of type func() string replaced by their results.
+// v is only copied if it contains such an argument.
+func resolveLazy(v []any) []any {
+	copied := false
+	for i, arg := range v {
+		f, ok := arg.(func() string)
+		if !ok {
+			continue
+		}
+		if !copied {
+			v = append([]any(nil), v...)
+			copied = true
+		}
+		v[i] = f()
+	}
+	return v
+}
+
+// resolveFields replaces the lazily evaluated values of the fields of rec by their results, so all encoders, hooks
+// and redactors see the same values. The fields are only copied if one of them is lazy.
+func resolveFields(rec *Record) {
+	copied := false
+	for i, f := range rec.Fields {
+		switch f.Value.(type) {
+		case Lazy, func() string:
+		default:
+			continue
+		}
+		if !copied {
+			rec.Fields = append([]Field(nil), rec.Fields...)
+			copied = true
+		}
+		rec.Fields[i].Value = resolveValue(f.Value)
+	}
+}
+
+// resolveValue returns the result of v if v is a lazily evaluated value, otherwise v.
+func resolveValue(v any) any {
+	switch f := v.(type) {
+	case Lazy:
+		return f()
+	case func() string:
+		return f()
+	}
+	return v
+}
@@ -0,0 +1,63 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	expensive := func() any {
+		calls++
+		return 42
+	}
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.Debugf("answer: %d", Lazy(expensive))
+	l.Debug(func() string { calls++; return "never" })
+	l.DebugKV("filtered", "answer", Lazy(expensive))
+	if calls != 0 {
+		t.Errorf("Lazy arguments of filtered records were evaluated %d times", calls)
+	}
+	l.Infof("answer: %03d", Lazy(expensive))
+	l.Info("answer: ", func() string { return "42" })
+	l.InfoKV("kv", "answer", Lazy(expensive))
+	l.SetFormat(FormatJSON)
+	l.InfoKV("json", "answer", Lazy(expensive))
+	if calls != 3 {
+		t.Errorf("Expected 3 evaluations, got %d", calls)
+	}
+	lines := strings.Split(b.String(), "\n")
+	expect := []string{"[Info] - answer: 042", "[Info] - answer: 42", "[Info] - kv - answer=42"}
+	for i, line := range expect {
+		if lines[i] != line {
+			t.Errorf("Expected %q. Got %q", line, lines[i])
+		}
+	}
+	if !strings.Contains(lines[3], `"fields":{"answer":42}`) {
+		t.Errorf("Lazy field was not encoded as JSON number: %q", lines[3])
+	}
+}
+
+func TestLazyResolvedOnce(t *testing.T) {
+	calls := 0
+	counter := func() any {
+		calls++
+		return calls
+	}
+	text, json := new(strings.Builder), new(strings.Builder)
+	l := New(text, LevelInfo, loglevelDelimiter)
+	l.AddFormatOutput(json, LevelInfo, FormatJSON)
+	l.InfoKV("count", "v", Lazy(counter))
+	if calls != 1 {
+		t.Errorf("Expected 1 evaluation, got %d", calls)
+	}
+	if text.String() != "[Info] - count - v=1\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - count - v=1\n", text.String())
+	}
+	if !strings.Contains(json.String(), `"fields":{"v":1}`) {
+		t.Errorf("Lazy field was evaluated again for the JSON output: %q", json.String())
+	}
+}
@@ -0,0 +1,148 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	LengthTruncate LengthPolicy = iota //Cut messages that exceed the maximum length and mark them by a trailing "...".
+	LengthSplit                        //Split messages that exceed the maximum length into continuation records, every record but the last ends with "...".
+	LengthDrop                         //Discard records whose message exceeds the maximum length.
+)
+
+// lengthMarker marks a message that was truncated or continues in the next record.
+const lengthMarker = "..."
+
+// Represents the way a Logger treats messages that exceed its maximum length.
+type LengthPolicy int
+
+// Panics if the length policy does not exist.
+func assertLengthPolicy(p LengthPolicy) {
+	if err := checkLengthPolicy(p); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Returns an error if the length policy does not exist.
+func checkLengthPolicy(p LengthPolicy) error {
+	if p < LengthTruncate || p > LengthDrop {
+		return fmt.Errorf("Length policy %d is not defined", p)
+	}
+	return nil
+}
+
+// String returns the string representation of a LengthPolicy. If the LengthPolicy is
+// not defined, String returns "Undefined".
+func (p LengthPolicy) String() string {
+	switch p {
+	case LengthTruncate:
+		return "Truncate"
+	case LengthSplit:
+		return "Split"
+	case LengthDrop:
+		return "Drop"
+	}
+	return "Undefined"
+}
+
+// ParseLengthPolicy returns the LengthPolicy whose string representation matches input, ignoring case.
+// On failure, it returns LengthTruncate and an error.
+func ParseLengthPolicy(input string) (LengthPolicy, error) {
+	switch strings.ToLower(input) {
+	case "truncate":
+		return LengthTruncate, nil
+	case "split":
+		return LengthSplit, nil
+	case "drop":
+		return LengthDrop, nil
+	}
+	return LengthTruncate, fmt.Errorf("Input sequence %q cannot be associated with a defined length policy", input)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the string representation of the LengthPolicy,
+// or an error if the LengthPolicy is not defined.
+func (p LengthPolicy) MarshalText() ([]byte, error) {
+	if err := checkLengthPolicy(p); err != nil {
+		return nil, err
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all input ParseLengthPolicy accepts.
+func (p *LengthPolicy) UnmarshalText(text []byte) error {
+	policy, err := ParseLengthPolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
+
+// MaxLength returns the maximum message length in bytes and the policy that applies to longer messages.
+// A length of 0 means that the length is not limited.
+func (l *Logger) MaxLength() (int, LengthPolicy) {
+	defer l.runlock(l.rlock())
+	return l.maxLength, l.lengthPolicy
+}
+
+// SetMaxLength limits the length of the messages written by the Logger to max bytes, a longer message is
+// treated according to policy. Records dropped by LengthDrop are counted, see Dropped. max <= 0 removes the limit (default).
+func (l *Logger) SetMaxLength(max int, policy LengthPolicy) {
+	assertLengthPolicy(policy)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if max < 0 {
+		max = 0
+	}
+	l.maxLength = max
+	l.lengthPolicy = policy
+}
+
+// limitLength applies the Logger's length policy to rec, whose message exceeds the maximum length.
+// It returns the records to write instead of rec, nil if rec is dropped. The caller must hold the Logger's lock.
+func (l *Logger) limitLength(rec *Record) []*Record {
+	marker := lengthMarker
+	if l.maxLength <= len(marker) {
+		marker = ""
+	}
+	switch l.lengthPolicy {
+	case LengthDrop:
+		l.dropped.Add(1)
+		return nil
+	case LengthSplit:
+		var recs []*Record
+		msg := rec.Message
+		for len(msg) > l.maxLength {
+			cut := cutUTF8(msg, l.maxLength-len(marker))
+			part := *rec
+			part.Message = msg[:cut] + marker
+			if len(recs) > 0 {
+				part.Stack = ""
+			}
+			recs = append(recs, &part)
+			msg = msg[cut:]
+		}
+		last := *rec
+		last.Message = msg
+		last.Stack = ""
+		return append(recs, &last)
+	}
+	rec.Message = rec.Message[:cutUTF8(rec.Message, l.maxLength-len(marker))] + marker
+	return []*Record{rec}
+}
+
+// cutUTF8 returns the largest index <= n that is not within a multibyte character of s.
+// The index is at least that of the second character, so s is always shortened.
+func cutUTF8(s string, n int) int {
+	for i := n; i > 0; i-- {
+		if utf8.RuneStart(s[i]) {
+			return i
+		}
+	}
+	_, size := utf8.DecodeRuneInString(s)
+	return size
+}
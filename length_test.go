@@ -0,0 +1,50 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaxLength(t *testing.T) {
+	tests := []struct {
+		policy   LengthPolicy
+		msg      string
+		expected string
+	}{
+		{LengthTruncate, "short", "[Info] - short\n"},
+		{LengthTruncate, "0123456789abc", "[Info] - 0123456...\n"},
+		{LengthTruncate, "012345äöü", "[Info] - 012345...\n"},
+		{LengthSplit, "0123456789abcdefghij", "[Info] - 0123456...\n[Info] - 789abcd...\n[Info] - efghij\n"},
+		{LengthDrop, "0123456789abc", ""},
+	}
+	for _, test := range tests {
+		out := new(strings.Builder)
+		l := New(out, LevelInfo, loglevelDelimiter)
+		l.SetMaxLength(10, test.policy)
+		l.Info(test.msg)
+		if got := out.String(); got != test.expected {
+			t.Errorf("%s: Expected %q. Got %q", test.policy, test.expected, got)
+		}
+	}
+}
+
+func TestMaxLengthDrop(t *testing.T) {
+	l := New(new(strings.Builder), LevelInfo, loglevelDelimiter)
+	l.SetMaxLength(3, LengthDrop)
+	l.Info("abc")
+	l.Info("abcd")
+	if l.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped record. Got %d", l.Dropped())
+	}
+}
+
+func TestCutUTF8(t *testing.T) {
+	if got := cutUTF8("äb", 1); got != 2 {
+		t.Errorf("Expected 2. Got %d", got)
+	}
+	if got := cutUTF8("aäb", 2); got != 1 {
+		t.Errorf("Expected 1. Got %d", got)
+	}
+}
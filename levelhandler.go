@@ -0,0 +1,55 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// levelPayload is the JSON document exchanged by LevelHandler.
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+// LevelHandler returns an http.Handler that lets operators query and change the Logger's loglevel at runtime.
+// A GET request returns the current loglevel as JSON, e.g. {"level":"Info"}. A PUT request sets the loglevel
+// from a JSON body of the same form and returns the new loglevel:
+//
+//	curl -X PUT -d '{"level":"debug"}' http://localhost:8080/loglevel
+//
+// All other methods are answered with 405 Method Not Allowed.
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			payload := new(levelPayload)
+			if err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(payload); err != nil {
+				writeLevelError(w, http.StatusBadRequest, fmt.Errorf("Malformed request body: %w", err))
+				return
+			}
+			level, err := ParseLevel(payload.Level)
+			if err != nil {
+				writeLevelError(w, http.StatusBadRequest, err)
+				return
+			}
+			l.SetLevel(level)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeLevelError(w, http.StatusMethodNotAllowed, fmt.Errorf("Method %s is not allowed", r.Method))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&levelPayload{Level: l.Level().String()})
+	})
+}
+
+// writeLevelError answers a request to LevelHandler with an error.
+func writeLevelError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,38 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLevelHandler(t *testing.T) {
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	h := l.LevelHandler()
+	for _, tc := range []struct {
+		method string
+		body   string
+		code   int
+		expect string
+	}{
+		{http.MethodGet, "", http.StatusOK, `{"level":"Info"}`},
+		{http.MethodPut, `{"level":"debug"}`, http.StatusOK, `{"level":"Debug"}`},
+		{http.MethodPut, `{"level":"verbose"}`, http.StatusBadRequest, ""},
+		{http.MethodPut, `level=debug`, http.StatusBadRequest, ""},
+		{http.MethodPost, `{"level":"info"}`, http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "", http.StatusOK, `{"level":"Debug"}`},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tc.method, "/loglevel", strings.NewReader(tc.body)))
+		if rec.Code != tc.code {
+			t.Errorf("%s %q: Expected status %d, got %d", tc.method, tc.body, tc.code, rec.Code)
+		}
+		if len(tc.expect) > 0 && strings.TrimSpace(rec.Body.String()) != tc.expect {
+			t.Errorf("%s %q: Expected body %q, got %q", tc.method, tc.body, tc.expect, rec.Body.String())
+		}
+	}
+}
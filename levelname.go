@@ -0,0 +1,154 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	LevelNameFull   LevelNameStyle = iota //Level tags show the full name of the loglevel, e.g. "[Warning]".
+	LevelNameShort                        //Level tags show a three letter abbreviation of the loglevel, e.g. "[WRN]".
+	LevelNameLetter                       //Level tags show a single letter, e.g. "[W]".
+)
+
+// shortLevelNames holds the abbreviations of the predefined loglevels.
+var shortLevelNames = map[Level]string{
+	LevelAudit:    "AUD",
+	LevelPanic:    "PNC",
+	LevelAlert:    "ALR",
+	LevelCritical: "CRT",
+	LevelError:    "ERR",
+	LevelWarning:  "WRN",
+	LevelNotice:   "NTC",
+	LevelInfo:     "INF",
+	LevelDebug:    "DBG",
+	LevelTrace:    "TRC",
+}
+
+// letterLevelNames holds the letters of the predefined loglevels whose letter differs from their initial.
+var letterLevelNames = map[Level]string{
+	LevelAudit: "U",
+}
+
+// Represents the way a Logger names the loglevels in the level tags of FormatText.
+type LevelNameStyle int
+
+// Panics if the level name style does not exist.
+func assertLevelNameStyle(style LevelNameStyle) {
+	if err := checkLevelNameStyle(style); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Returns an error if the level name style does not exist.
+func checkLevelNameStyle(style LevelNameStyle) error {
+	if style < LevelNameFull || style > LevelNameLetter {
+		return fmt.Errorf("Level name style %d is not defined", style)
+	}
+	return nil
+}
+
+// String returns the string representation of a LevelNameStyle. If the LevelNameStyle is
+// not defined, String returns "Undefined".
+func (style LevelNameStyle) String() string {
+	switch style {
+	case LevelNameFull:
+		return "Full"
+	case LevelNameShort:
+		return "Short"
+	case LevelNameLetter:
+		return "Letter"
+	}
+	return "Undefined"
+}
+
+// ParseLevelNameStyle returns the LevelNameStyle whose string representation matches input, ignoring case.
+// On failure, it returns LevelNameFull and an error.
+func ParseLevelNameStyle(input string) (LevelNameStyle, error) {
+	switch strings.ToLower(input) {
+	case "full":
+		return LevelNameFull, nil
+	case "short":
+		return LevelNameShort, nil
+	case "letter":
+		return LevelNameLetter, nil
+	}
+	return LevelNameFull, fmt.Errorf("Input sequence %q cannot be associated with a defined level name style", input)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the string representation of the LevelNameStyle,
+// or an error if the LevelNameStyle is not defined.
+func (style LevelNameStyle) MarshalText() ([]byte, error) {
+	if err := checkLevelNameStyle(style); err != nil {
+		return nil, err
+	}
+	return []byte(style.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all input ParseLevelNameStyle accepts.
+func (style *LevelNameStyle) UnmarshalText(text []byte) error {
+	s, err := ParseLevelNameStyle(string(text))
+	if err != nil {
+		return err
+	}
+	*style = s
+	return nil
+}
+
+// LevelNameStyle returns the way the Logger names the loglevels in its level tags.
+func (l *Logger) LevelNameStyle() LevelNameStyle {
+	defer l.runlock(l.rlock())
+	return l.levelNameStyle
+}
+
+// SetLevelNameStyle sets the way the Logger names the loglevels in the level tags of FormatText. The short names
+// of registered loglevels are the first three letters of their names in upper case. Other formats always
+// use the full names. Setting an invalid style will cause a panic.
+func (l *Logger) SetLevelNameStyle(style LevelNameStyle) {
+	assertLevelNameStyle(style)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levelNameStyle = style
+}
+
+// levelName returns the name of lvl in the Logger's level name style. The caller must hold the Logger's lock.
+func (l *Logger) levelName(lvl Level) string {
+	switch l.levelNameStyle {
+	case LevelNameShort:
+		if name, ok := shortLevelNames[lvl]; ok {
+			return name
+		}
+		return abbreviate(lvl.String(), 3)
+	case LevelNameLetter:
+		if name, ok := letterLevelNames[lvl]; ok {
+			return name
+		}
+		return abbreviate(lvl.String(), 1)
+	}
+	return lvl.String()
+}
+
+// levelNameWidth returns the number of characters of the longest loglevel name in the Logger's level name style.
+// The caller must hold the Logger's lock.
+func (l *Logger) levelNameWidth() int {
+	switch l.levelNameStyle {
+	case LevelNameShort:
+		return 3
+	case LevelNameLetter:
+		return 1
+	}
+	return maxLevelNameWidth()
+}
+
+// abbreviate returns the first n characters of name in upper case.
+func abbreviate(name string, n int) string {
+	for i := range name {
+		if n == 0 {
+			return strings.ToUpper(name[:i])
+		}
+		n--
+	}
+	return strings.ToUpper(name)
+}
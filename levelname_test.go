@@ -0,0 +1,38 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLevelNameStyle(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetLevelNameStyle(LevelNameShort)
+	l.Warning("short")
+	l.SetLevelNameStyle(LevelNameLetter)
+	l.Error("letter")
+	l.Audit("audit")
+	l.SetLevelNameStyle(LevelNameShort)
+	l.SetPadLevel(true)
+	l.Info("padded")
+	expected := "[WRN] - short\n[E] - letter\n[U] - audit\n[INF] - padded\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	for lvl, name := range shortLevelNames {
+		if parsed, err := ParseLevel(name); err != nil || parsed != lvl {
+			t.Errorf("Expected %s. Got %s, %v", lvl, parsed, err)
+		}
+	}
+}
+
+func TestAbbreviate(t *testing.T) {
+	for input, expected := range map[string]string{"Chatter": "CHA", "äb": "ÄB", "Long": "LON"} {
+		if got := abbreviate(input, 3); got != expected {
+			t.Errorf("Expected %q. Got %q", expected, got)
+		}
+	}
+}
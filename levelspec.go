@@ -0,0 +1,93 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LevelSpec holds loglevels for named loggers, see ParseLevelSpec and SetLevelSpec.
+type LevelSpec struct {
+	Default Level            // Loglevel of the default logger and of named loggers without an entry, LevelInvalid keeps their loglevel.
+	Levels  map[string]Level // Loglevels by the name of a logger.
+}
+
+// ParseLevelSpec parses a comma-separated list of loglevels like "info,db=debug,http=warning", similar to
+// the environment variables RUST_LOG or GST_DEBUG. An entry without a name sets the default loglevel, an entry
+// "name=level" sets the loglevel of the named logger name and of its descendants like "name.sub", see Named.
+// Loglevels are parsed by ParseLevel, later entries override earlier ones.
+func ParseLevelSpec(input string) (LevelSpec, error) {
+	spec := LevelSpec{Levels: make(map[string]Level)}
+	for _, entry := range strings.Split(input, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) < 1 {
+			continue
+		}
+		name, level, named := strings.Cut(entry, "=")
+		if !named {
+			level = name
+		}
+		lvl, err := ParseLevel(level)
+		if err != nil {
+			return LevelSpec{}, fmt.Errorf("Level spec %q: %w", input, err)
+		}
+		if !named {
+			spec.Default = lvl
+			continue
+		}
+		if name = strings.TrimSpace(name); len(name) < 1 {
+			return LevelSpec{}, fmt.Errorf("Level spec %q: Entry %q has no name", input, entry)
+		}
+		spec.Levels[name] = lvl
+	}
+	return spec, nil
+}
+
+// Level returns the loglevel of the named logger name. It is the loglevel of the longest entry that equals name
+// or is a parent of it, e.g. "db" for "db.sql", or the default loglevel if no entry matches.
+func (s LevelSpec) Level(name string) Level {
+	for {
+		if lvl, ok := s.Levels[name]; ok {
+			return lvl
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return s.Default
+		}
+		name = name[:i]
+	}
+}
+
+// String returns the LevelSpec in the format accepted by ParseLevelSpec, with the entries sorted by name.
+func (s LevelSpec) String() string {
+	var entries []string
+	if s.Default != LevelInvalid {
+		entries = append(entries, strings.ToLower(s.Default.String()))
+	}
+	names := make([]string, 0, len(s.Levels))
+	for name := range s.Levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entries = append(entries, name+"="+strings.ToLower(s.Levels[name].String()))
+	}
+	return strings.Join(entries, ",")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s LevelSpec) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseLevelSpec.
+func (s *LevelSpec) UnmarshalText(text []byte) error {
+	spec, err := ParseLevelSpec(string(text))
+	if err != nil {
+		return err
+	}
+	*s = spec
+	return nil
+}
@@ -0,0 +1,35 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"testing"
+)
+
+func TestParseLevelSpec(t *testing.T) {
+	spec, err := ParseLevelSpec(" info, db=debug ,http=Warning,db.sql=trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]Level{
+		"":            LevelInfo,
+		"db":          LevelDebug,
+		"db.cache":    LevelDebug,
+		"db.sql":      LevelTrace,
+		"db.sql.stmt": LevelTrace,
+		"http":        LevelWarning,
+		"httpd":       LevelInfo,
+	} {
+		if lvl := spec.Level(name); lvl != expected {
+			t.Errorf("Expected level %s for %q, got level %s", expected, name, lvl)
+		}
+	}
+	if s := spec.String(); s != "info,db=debug,db.sql=trace,http=warning" {
+		t.Errorf("Expected %q. Got %q", "info,db=debug,db.sql=trace,http=warning", s)
+	}
+	for _, invalid := range []string{"verbose", "db=verbose", "=debug"} {
+		if _, err := ParseLevelSpec(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
@@ -8,21 +8,94 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Logger is the data type used for sending log records to.
 type Logger struct {
-	mu         *sync.Mutex
-	delimiter  string
-	timeFormat string
-	level      Level
-	out        io.Writer
+	*core
+	level  *loglevel
+	prefix string
+	fields []Field
+}
+
+// loglevel holds the loglevel of a Logger and the functions notified of its changes. It is shared with the loggers
+// derived by WithPrefix and WithFields, a named logger has one of its own, see Named.
+type loglevel struct {
+	atomic.Int32
+	listeners []func(old, new Level) // Protected by the Logger's lock.
+}
+
+// core holds the state of a Logger that is shared with its child loggers.
+type core struct {
+	mu              *sync.RWMutex
+	reentry         *reentrancy
+	throttle        *throttle
+	delimiter       string
+	timeFormat      string
+	timeStyle       TimeStyle
+	created         time.Time
+	clock           atomic.Pointer[func() time.Time] // See SetClock, nil means time.Now.
+	format          Format
+	out             io.Writer
+	outputs         []output
+	levelOutputs    map[Level]io.Writer
+	sinks           []sinkOutput
+	reportCaller    bool
+	callerSkip      int
+	stacktraceLevel Level
+	extractor       ContextExtractor
+	async           *asyncQueue
+	shards          *shardQueue
+	color           ColorMode
+	colorize        bool
+	exitHooks       []func()
+	exitFunc        func(code int)
+	exitCode        int // Exit code of Die and Dief, 0 means defaultExitCode.
+	deferredExit    bool
+	crashFile       string
+	assertAction    atomic.Int32 // See SetAssertAction.
+	hooks           map[Level][]Hook
+	sampler         Sampler
+	dedup           *dedup
+	errorHandler    func(error)
+	errorPolicy     ErrorPolicy
+	writeTimeout    time.Duration
+	maxLength       int
+	lengthPolicy    LengthPolicy
+	stalled         []stalledWrite
+	layout          []Segment
+	template        *template
+	padLevel        bool
+	levelNameStyle  LevelNameStyle
+	decorations     map[Level]string
+	transformers    map[Level][]Transformer
+	redactors       []Redactor
+	filters         []Filter
+	secretPolicy    SecretPolicy
+	escapeDelimiter bool
+	includeHostname bool
+	includePID      bool
+	deterministic   atomic.Bool
+	groupStyle      GroupStyle
+	groups          atomic.Int32 // Number of groups the Logger's records belong to, see Group.
+	discard         bool
+	closed          atomic.Bool
+	owned           []io.Closer // Writers the Logger opened itself, which Close closes, see Config.Build.
+	statsMu         sync.Mutex  // Protects records, so Stats does not need the Logger's lock.
+	records         map[Level]uint64
+	bytesWritten    atomic.Uint64
+	diskGuard       *diskGuard
+	recorder        *flightRecorder
+	recording       atomic.Bool // The Logger has a flight recorder, see SetFlightRecorder.
+	writeErrors     atomic.Uint64
+	dropped         atomic.Uint64 // Number of discarded records, see Dropped.
 }
 
 // New constructs a new Logger. It will print a log record to its given writer if it fulfills the
@@ -36,12 +109,56 @@ func New(w io.Writer, level Level, delimiter string) *Logger {
 		panic("Programming error: logger.New: Passed nil as output writer")
 	}
 	assertLoglevel(level)
-	return &Logger{
+	return newLogger(w, level, delimiter)
+}
+
+// NewE constructs a new Logger like New, but returns an error instead of panicking if an argument is invalid.
+func NewE(w io.Writer, level Level, delimiter string) (*Logger, error) {
+	if len(delimiter) < 1 {
+		return nil, errors.New("Delimiter must not be empty")
+	}
+	if w == nil {
+		return nil, errors.New("Output writer must not be nil")
+	}
+	if err := checkLoglevel(level); err != nil {
+		return nil, err
+	}
+	return newLogger(w, level, delimiter), nil
+}
+
+// NewStderr constructs a new Logger that writes to os.Stderr with the delimiter " - ", timestamps in
+// time.RFC3339 and colors if os.Stderr is a terminal. Setting an invalid loglevel will cause a panic.
+func NewStderr(level Level) *Logger {
+	return newConsole(os.Stderr, level)
+}
+
+// NewStdout constructs a new Logger like NewStderr, but writes to os.Stdout.
+func NewStdout(level Level) *Logger {
+	return newConsole(os.Stdout, level)
+}
+
+// newConsole constructs the Logger of NewStderr and NewStdout.
+func newConsole(w io.Writer, level Level) *Logger {
+	assertLoglevel(level)
+	l := newLogger(w, level, defaultDelimiter)
+	l.timeFormat = time.RFC3339
+	l.color = ColorAuto
+	l.updateColorize()
+	return l
+}
+
+// newLogger constructs a new Logger from validated arguments.
+func newLogger(w io.Writer, level Level, delimiter string) *Logger {
+	l := &Logger{core: &core{
+		created:   time.Now(),
 		delimiter: delimiter,
-		level:     level,
-		mu:        new(sync.Mutex),
+		mu:        new(sync.RWMutex),
+		reentry:   new(reentrancy),
+		throttle:  new(throttle),
 		out:       w,
-	}
+	}, level: new(loglevel)}
+	l.level.Store(int32(level))
+	return l
 }
 
 // Alert sends a message of loglevel LevelAlert to the Logger.
@@ -64,16 +181,18 @@ func (l *Logger) Criticalf(format string, a ...any) (n int, err error) {
 	return l.Printf(LevelCritical, format, a...)
 }
 
-// Die sends a message of loglevel LevelPanic to the Logger, then exits with code 1.
+// Die sends a message of loglevel LevelPanic to the Logger, runs the exit hooks, then exits with the
+// Logger's exit code, see SetExitCode.
 func (l *Logger) Die(v ...any) {
 	l.Panic(v...)
-	os.Exit(1)
+	l.exit(l.ExitCode())
 }
 
-// Dief sends a formatted message of loglevel LevelPanic to the Logger, then exits with code 1.
+// Dief sends a formatted message of loglevel LevelPanic to the Logger, runs the exit hooks, then exits with the
+// Logger's exit code, see SetExitCode.
 func (l *Logger) Dief(format string, a ...any) {
 	l.Panicf(format, a...)
-	os.Exit(1)
+	l.exit(l.ExitCode())
 }
 
 // Debug sends a message of loglevel LevelDebug to the Logger.
@@ -86,6 +205,12 @@ func (l *Logger) Debugf(format string, a ...any) (n int, err error) {
 	return l.Printf(LevelDebug, format, a...)
 }
 
+// Enabled returns true if the Logger writes records of the given loglevel or keeps them in its flight recorder.
+// It can be used to guard the construction of expensive log arguments. Enabled does not acquire the Logger's lock.
+func (l *Logger) Enabled(level Level) bool {
+	return !l.discard && (l.trigger(level) || l.recording.Load())
+}
+
 // Error sends a message of loglevel LevelError to the Logger.
 func (l *Logger) Error(v ...any) (n int, err error) {
 	return l.Println(LevelError, v...)
@@ -96,6 +221,12 @@ func (l *Logger) Errorf(format string, a ...any) (n int, err error) {
 	return l.Printf(LevelError, format, a...)
 }
 
+// Format returns the output format the Logger currently uses for its log records.
+func (l *Logger) Format() Format {
+	defer l.runlock(l.rlock())
+	return l.format
+}
+
 // Info sends a message of loglevel LevelInfo to the Logger.
 func (l *Logger) Info(v ...any) (n int, err error) {
 	return l.Println(LevelInfo, v...)
@@ -108,9 +239,7 @@ func (l *Logger) Infof(format string, a ...any) (n int, err error) {
 
 // Level returns the Logger's current loglevel as an integer.
 func (l *Logger) Level() Level {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	return l.level
+	return Level(l.level.Load())
 }
 
 // Notice sends a message of loglevel LevelNotice to the Logger.
@@ -135,63 +264,107 @@ func (l *Logger) Panicf(format string, a ...any) (n int, err error) {
 	return l.Printf(LevelPanic, format, a...)
 }
 
+// PanicNow sends a message of loglevel LevelPanic to the Logger, then calls panic() with the message.
+func (l *Logger) PanicNow(v ...any) {
+	msg := fmt.Sprint(v...)
+	l.Output(Record{Level: LevelPanic, Message: msg})
+	l.Flush()
+	panic(msg)
+}
+
+// PanicNowf sends a formatted message of loglevel LevelPanic to the Logger, then calls panic() with the message.
+func (l *Logger) PanicNowf(format string, a ...any) {
+	msg := fmt.Sprintf(format, a...)
+	l.Output(Record{Level: LevelPanic, Message: msg})
+	l.Flush()
+	panic(msg)
+}
+
 // Println writes the log message if its log level is equally severe or more severe than that set for the Logger.
+// The arguments are only formatted if the message is written, see Lazy.
 func (l *Logger) Println(level Level, v ...any) (n int, err error) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	if !l.trigger(level) {
+	if !l.Enabled(level) {
+		return 0, nil
+	}
+	return l.Output(Record{Level: level, Message: sprint(resolveLazy(v))})
+}
+
+// PrintlnOK writes the log message like Println and additionally reports whether it was written, see OutputOK.
+func (l *Logger) PrintlnOK(level Level, v ...any) (n int, ok bool, err error) {
+	if !l.Enabled(level) {
+		return 0, false, nil
+	}
+	return l.OutputOK(Record{Level: level, Message: sprint(resolveLazy(v))})
+}
+
+// PrintString writes s as the log message if the logger was configured to print the given level.
+// Unlike Println, s is used as is without passing it through fmt, which suits pre-rendered messages.
+func (l *Logger) PrintString(level Level, s string) (n int, err error) {
+	if !l.Enabled(level) {
 		return 0, nil
 	}
-	if len(l.timeFormat) > 0 {
-		return fmt.Fprintf(l.out,
-			"[%s]%s%s%s%s\n",
-			level.String(),
-			l.delimiter,
-			time.Now().Format(l.timeFormat),
-			l.delimiter,
-			fmt.Sprint(v...))
-	} else {
-		return fmt.Fprintf(l.out,
-			"[%s]%s%s\n",
-			level.String(),
-			l.delimiter,
-			fmt.Sprint(v...))
+	return l.Output(Record{Level: level, Message: s})
+}
+
+// PrintBytes writes b as the log message like PrintString. b is copied, the caller may reuse it after PrintBytes returns.
+func (l *Logger) PrintBytes(level Level, b []byte) (n int, err error) {
+	if !l.Enabled(level) {
+		return 0, nil
 	}
+	return l.Output(Record{Level: level, Message: string(b)})
 }
 
 // Printf writes a formatted log message if the logger was configured to print the given level.
+// The arguments are only formatted if the message is written, see Lazy.
+// A trailing newline in the formatted message is optional, the record will always end with exactly one.
 func (l *Logger) Printf(level Level, format string, a ...any) (n int, err error) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	if !l.trigger(level) {
+	if !l.Enabled(level) {
 		return 0, nil
 	}
-	if len(l.timeFormat) > 0 {
-		return fmt.Fprintf(l.out,
-			"[%s]%s%s%s%s",
-			level.String(),
-			l.delimiter,
-			time.Now().Format(l.timeFormat),
-			l.delimiter,
-			l.autoAppendLF(fmt.Sprintf(format, a...)))
-	} else {
-		return fmt.Fprintf(l.out,
-			"[%s]%s%s",
-			level.String(),
-			l.delimiter,
-			l.autoAppendLF(fmt.Sprintf(format, a...)))
+	return l.Output(Record{Level: level, Message: fmt.Sprintf(format, resolveLazy(a)...)})
+}
+
+// PrintfOK writes a formatted log message like Printf and additionally reports whether it was written, see OutputOK.
+func (l *Logger) PrintfOK(level Level, format string, a ...any) (n int, ok bool, err error) {
+	if !l.Enabled(level) {
+		return 0, false, nil
 	}
+	return l.OutputOK(Record{Level: level, Message: fmt.Sprintf(format, resolveLazy(a)...)})
+}
+
+// SetFormat changes the output format of the Logger's log records. Setting an invalid format will cause a panic.
+func (l *Logger) SetFormat(format Format) {
+	assertFormat(format)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.format = format
 }
 
 // SetLevel sets a new loglevel for the Logger. Setting an invalid loglevel will cause a panic.
 func (l *Logger) SetLevel(level Level) {
 	assertLoglevel(level)
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.level = level
+	l.setLevel(level)
+}
+
+// SetLevelE sets a new loglevel for the Logger like SetLevel, but returns an error instead of panicking
+// if the loglevel is invalid.
+func (l *Logger) SetLevelE(level Level) error {
+	if err := checkLoglevel(level); err != nil {
+		return err
+	}
+	l.setLevel(level)
+	return nil
 }
 
-// SetOutput changes the writer the Logger will write its messages to.
+// setLevel sets a validated loglevel and notifies the level change listeners.
+func (l *Logger) setLevel(level Level) {
+	locked := l.rlock()
+	old, listeners := Level(l.level.Swap(int32(level))), l.level.listeners
+	l.runlock(locked)
+	notifyLevelChange(listeners, old, level)
+}
+
+// SetOutput changes the writer the Logger will write its messages to. Outputs added by AddOutput are not affected.
 func (l *Logger) SetOutput(w io.Writer) {
 	if w == nil {
 		panic("Programming error: (l *Logger) SetOutput(): Passed nil as output writer")
@@ -199,11 +372,12 @@ func (l *Logger) SetOutput(w io.Writer) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.out = w
+	l.updateColorize()
 }
 
 // SetTimeFormat takes a format string as defined in the "(t Time) Format" function of go's "time" module.
 // If such a string is set, log records will display a timestamp formatted like specified by the format string.
-// To remove timestamps from future log records, set the format string to "".
+// To remove timestamps from future log records, set the format string to "". See also SetTimeStyle.
 func (l *Logger) SetTimeFormat(format string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -212,11 +386,20 @@ func (l *Logger) SetTimeFormat(format string) {
 
 // TimeFormat returns the current format string for the timestamp. If it returns "", log records will have no timestamp.
 func (l *Logger) TimeFormat() string {
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	defer l.runlock(l.rlock())
 	return l.timeFormat
 }
 
+// Trace sends a message of loglevel LevelTrace to the Logger.
+func (l *Logger) Trace(v ...any) (n int, err error) {
+	return l.Println(LevelTrace, v...)
+}
+
+// Tracef sends a formatted message of loglevel LevelTrace to the Logger.
+func (l *Logger) Tracef(format string, a ...any) (n int, err error) {
+	return l.Printf(LevelTrace, format, a...)
+}
+
 // Warning sends a message of loglevel LevelWarning to the Logger.
 func (l *Logger) Warning(v ...any) (n int, err error) {
 	return l.Println(LevelWarning, v...)
@@ -228,20 +411,21 @@ func (l *Logger) Warningf(format string, a ...any) (n int, err error) {
 }
 
 // trigger returns true if the Logger should print a message of loglevel
-// level, otherwise it returns false.
+// level, otherwise it returns false. The loglevel is read atomically, the caller does not need to hold the Logger's lock.
 func (l *Logger) trigger(lvl Level) bool {
 	assertLoglevel(lvl)
-	if lvl <= l.level {
+	if lvl <= Level(l.level.Load()) {
 		return true
 	}
 	return false
 }
 
-// autoAppendLF appends one newline character at the end of input and returns
-// a new string if input doesn't already end with a newline character.
-func (l *Logger) autoAppendLF(input string) string {
-	if strings.HasSuffix(input, "\n") {
-		return input
+// sprint formats v like fmt.Sprint. A single string is returned as is without formatting.
+func sprint(v []any) string {
+	if len(v) == 1 {
+		if s, ok := v[0].(string); ok {
+			return s
+		}
 	}
-	return input + "\n"
+	return fmt.Sprint(v...)
 }
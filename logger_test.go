@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 const loglevelDelimiter = " - "
@@ -34,14 +35,14 @@ func doesnotlog(t *testing.T, loglevel Level, messageLevel Level, msg string) {
 
 func TestLoglevels(t *testing.T) {
 	const msg = "Test Message!"
-	for loglevel := LevelDebug; loglevel >= LevelPanic; loglevel-- {
+	for loglevel := LevelTrace; loglevel >= LevelPanic; loglevel-- {
 		for messageLevel := loglevel; messageLevel >= LevelPanic; messageLevel-- {
 			doeslog(t, loglevel, messageLevel, msg)
 		}
 	}
 
-	for loglevel := LevelPanic; loglevel <= LevelInfo; loglevel++ {
-		for messageLevel := loglevel + 1; messageLevel <= LevelDebug; messageLevel++ {
+	for loglevel := LevelPanic; loglevel <= LevelDebug; loglevel++ {
+		for messageLevel := loglevel + 1; messageLevel <= LevelTrace; messageLevel++ {
 			doesnotlog(t, loglevel, messageLevel, msg)
 		}
 	}
@@ -51,9 +52,9 @@ func TestPrintShortcuts(t *testing.T) {
 	const msg = "This is a sample log record"
 	var expect string
 	b := new(strings.Builder)
-	l := New(b, LevelDebug, loglevelDelimiter)
+	l := New(b, LevelTrace, loglevelDelimiter)
 
-	for logLevel := LevelPanic; logLevel <= LevelDebug; logLevel++ {
+	for logLevel := LevelPanic; logLevel <= LevelTrace; logLevel++ {
 		switch logLevel {
 		case LevelPanic:
 			l.Panic(msg)
@@ -71,6 +72,8 @@ func TestPrintShortcuts(t *testing.T) {
 			l.Info(msg)
 		case LevelDebug:
 			l.Debug(msg)
+		case LevelTrace:
+			l.Trace(msg)
 		default:
 			t.Fatalf("Unknown loglevel %d", logLevel)
 		}
@@ -86,9 +89,9 @@ func TestPrintfShortcuts(t *testing.T) {
 	const msg = "This is a sample log record for"
 	var expect string
 	b := new(strings.Builder)
-	l := New(b, LevelDebug, loglevelDelimiter)
+	l := New(b, LevelTrace, loglevelDelimiter)
 
-	for logLevel := LevelPanic; logLevel <= LevelDebug; logLevel++ {
+	for logLevel := LevelPanic; logLevel <= LevelTrace; logLevel++ {
 		switch logLevel {
 		case LevelPanic:
 			l.Panicf("%s %s", msg, logLevel.String())
@@ -106,6 +109,8 @@ func TestPrintfShortcuts(t *testing.T) {
 			l.Infof("%s %s", msg, logLevel.String())
 		case LevelDebug:
 			l.Debugf("%s %s", msg, logLevel.String())
+		case LevelTrace:
+			l.Tracef("%s %s", msg, logLevel.String())
 		default:
 			t.Fatalf("Unknown loglevel %d", logLevel)
 		}
@@ -141,6 +146,21 @@ func TestPrintfAutoLF(t *testing.T) {
 	b.Reset()
 }
 
+func TestPrintString(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.PrintString(LevelInfo, "100% pre-rendered\n")
+	msg := []byte("proxied %v")
+	l.PrintBytes(LevelWarning, msg)
+	copy(msg, "changed")
+	l.PrintString(LevelDebug, "filtered")
+	l.PrintBytes(LevelDebug, msg)
+	expected := "[Info] - 100% pre-rendered\n[Warning] - proxied %v\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
 // Checks if the mutex lock works correctly
 func TestMutex(t *testing.T) {
 	const messageLength = 36
@@ -174,3 +194,80 @@ func testMutexWorker(wg *sync.WaitGroup, l *Logger, id int) {
 	}
 	wg.Done()
 }
+
+func TestNewE(t *testing.T) {
+	b := new(strings.Builder)
+	if _, err := NewE(b, LevelInvalid, loglevelDelimiter); err == nil {
+		t.Error("Expected an error for an invalid loglevel")
+	}
+	if _, err := NewE(b, LevelInfo, ""); err == nil {
+		t.Error("Expected an error for an empty delimiter")
+	}
+	if _, err := NewE(nil, LevelInfo, loglevelDelimiter); err == nil {
+		t.Error("Expected an error for a nil writer")
+	}
+	l, err := NewE(b, LevelInfo, loglevelDelimiter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetLevelE(LevelTrace + 1); err == nil {
+		t.Error("Expected an error for an invalid loglevel")
+	}
+	if err := l.SetLevelE(LevelDebug); err != nil || l.Level() != LevelDebug {
+		t.Errorf("Expected level %s, got level %s and error %v", LevelDebug, l.Level(), err)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	l := New(new(strings.Builder), LevelNotice, loglevelDelimiter)
+	for level := LevelPanic; level <= LevelDebug; level++ {
+		if expected := level <= LevelNotice; l.Enabled(level) != expected {
+			t.Errorf("Expected Enabled(%s) to be %t", level, expected)
+		}
+	}
+	if Discard().Enabled(LevelPanic) {
+		t.Error("Expected a discard logger to have no enabled level")
+	}
+}
+
+func TestLevelConcurrent(t *testing.T) {
+	l := New(new(syncBuilder), LevelInfo, loglevelDelimiter)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			l.SetLevel(LevelPanic + Level(i%int(LevelDebug)))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			l.Enabled(LevelDebug)
+			l.Debug("message")
+		}
+	}()
+	wg.Wait()
+	l.SetLevel(LevelWarning)
+	if l.Level() != LevelWarning || l.Enabled(LevelNotice) {
+		t.Errorf("Expected level %s. Got %s", LevelWarning, l.Level())
+	}
+}
+
+func BenchmarkFiltered(b *testing.B) {
+	l := New(new(strings.Builder), LevelInfo, loglevelDelimiter)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Debug("filtered")
+		}
+	})
+}
+
+func TestNewStderr(t *testing.T) {
+	for _, l := range []*Logger{NewStderr(LevelWarning), NewStdout(LevelWarning)} {
+		if l.Level() != LevelWarning || l.TimeFormat() != time.RFC3339 || l.Color() != ColorAuto {
+			t.Errorf("Unexpected settings: level %s, time format %q, color %s", l.Level(), l.TimeFormat(), l.Color())
+		}
+	}
+}
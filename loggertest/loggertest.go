@@ -0,0 +1,187 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+// The package loggertest provides a capturing Logger for unit tests. Instead of scraping the text output of a
+// Logger, tests can inspect the records it received and assert on them with matchers:
+//
+//	l, c := loggertest.NewCapture()
+//	doSomething(l)
+//	c.AssertCount(t, 1, loggertest.Level(logger.LevelWarning), loggertest.Contains("disk full"))
+package loggertest
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jwdev42/logger"
+)
+
+// Matcher reports whether a record fulfills a condition.
+type Matcher func(rec *logger.Record) bool
+
+// Capture stores the records of a Logger. It can be used by multiple goroutines.
+type Capture struct {
+	mu      sync.Mutex
+	records []logger.Record
+}
+
+// NewCapture returns a Logger at LevelDebug and the Capture that receives all of its records.
+// The Logger does not write any text output. Records of LevelTrace are captured once the Logger's level is raised.
+func NewCapture() (*logger.Logger, *Capture) {
+	c := new(Capture)
+	l := logger.New(io.Discard, logger.LevelDebug, " - ")
+	l.AddSink(c, logger.LevelTrace)
+	return l, c
+}
+
+// WriteRecord stores a copy of rec, it implements logger.Sink.
+func (c *Capture) WriteRecord(rec *logger.Record) error {
+	cp := *rec
+	cp.Fields = append([]logger.Field(nil), rec.Fields...)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = append(c.records, cp)
+	return nil
+}
+
+// Records returns all captured records in the order they were logged.
+func (c *Capture) Records() []logger.Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]logger.Record(nil), c.records...)
+}
+
+// Filter returns the captured records that match all matchers.
+func (c *Capture) Filter(matchers ...Matcher) []logger.Record {
+	var records []logger.Record
+	for _, rec := range c.Records() {
+		if matchAll(&rec, matchers) {
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
+// Count returns the number of captured records that match all matchers.
+func (c *Capture) Count(matchers ...Matcher) int {
+	return len(c.Filter(matchers...))
+}
+
+// LastMessage returns the message of the last captured record of the given level.
+// ok is false if no record of that level has been captured.
+func (c *Capture) LastMessage(level logger.Level) (msg string, ok bool) {
+	records := c.Filter(Level(level))
+	if len(records) < 1 {
+		return "", false
+	}
+	return records[len(records)-1].Message, true
+}
+
+// Reset discards all captured records.
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = nil
+}
+
+// AssertCount reports a test error if the number of captured records that match all matchers is not n.
+func (c *Capture) AssertCount(t testing.TB, n int, matchers ...Matcher) {
+	t.Helper()
+	if count := c.Count(matchers...); count != n {
+		t.Errorf("Expected %d matching records, got %d. Captured records:\n%s", n, count, c.dump())
+	}
+}
+
+// AssertNone reports a test error if a captured record matches all matchers.
+func (c *Capture) AssertNone(t testing.TB, matchers ...Matcher) {
+	t.Helper()
+	c.AssertCount(t, 0, matchers...)
+}
+
+// dump returns the captured records as text, one record per line.
+func (c *Capture) dump() string {
+	b := new(strings.Builder)
+	for _, rec := range c.Records() {
+		fmt.Fprintf(b, "[%s] %s %v\n", rec.Level, rec.Message, rec.Fields)
+	}
+	return b.String()
+}
+
+// matchAll returns true if rec matches all matchers.
+func matchAll(rec *logger.Record, matchers []Matcher) bool {
+	for _, m := range matchers {
+		if !m(rec) {
+			return false
+		}
+	}
+	return true
+}
+
+// Level matches records of the given level.
+func Level(level logger.Level) Matcher {
+	return func(rec *logger.Record) bool {
+		return rec.Level == level
+	}
+}
+
+// AtLeast matches records that are as severe as or more severe than level.
+func AtLeast(level logger.Level) Matcher {
+	return func(rec *logger.Record) bool {
+		return rec.Level <= level
+	}
+}
+
+// Message matches records whose message equals msg.
+func Message(msg string) Matcher {
+	return func(rec *logger.Record) bool {
+		return rec.Message == msg
+	}
+}
+
+// Contains matches records whose message contains substr.
+func Contains(substr string) Matcher {
+	return func(rec *logger.Record) bool {
+		return strings.Contains(rec.Message, substr)
+	}
+}
+
+// Prefix matches records logged by a Logger with the given prefix.
+func Prefix(prefix string) Matcher {
+	return func(rec *logger.Record) bool {
+		return rec.Prefix == prefix
+	}
+}
+
+// HasField matches records that carry a field with the given key.
+func HasField(key string) Matcher {
+	return func(rec *logger.Record) bool {
+		for _, f := range rec.Fields {
+			if f.Key == key {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Field matches records that carry a field with the given key and a value deeply equal to value.
+func Field(key string, value any) Matcher {
+	return func(rec *logger.Record) bool {
+		for _, f := range rec.Fields {
+			if f.Key == key && reflect.DeepEqual(f.Value, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches records that do not match m.
+func Not(m Matcher) Matcher {
+	return func(rec *logger.Record) bool {
+		return !m(rec)
+	}
+}
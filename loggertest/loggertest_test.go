@@ -0,0 +1,31 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package loggertest
+
+import (
+	"testing"
+
+	"github.com/jwdev42/logger"
+)
+
+func TestCapture(t *testing.T) {
+	l, c := NewCapture()
+	l.Warning("disk almost full")
+	l.WithPrefix("db").ErrorKV("query failed", "table", "users", "retries", 3)
+	l.Debug("tick")
+	c.AssertCount(t, 1, Level(logger.LevelWarning), Contains("disk"))
+	c.AssertCount(t, 1, Prefix("db"), Field("retries", 3), HasField("table"))
+	c.AssertCount(t, 2, AtLeast(logger.LevelWarning))
+	c.AssertNone(t, Level(logger.LevelPanic))
+	c.AssertCount(t, 2, Not(Message("tick")))
+	if msg, ok := c.LastMessage(logger.LevelError); !ok || msg != "query failed" {
+		t.Errorf("Expected last error %q, got %q", "query failed", msg)
+	}
+	if len(c.Records()) != 3 {
+		t.Errorf("Expected 3 records, got %d", len(c.Records()))
+	}
+	c.Reset()
+	if _, ok := c.LastMessage(logger.LevelError); ok {
+		t.Error("Reset did not discard the records")
+	}
+}
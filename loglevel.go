@@ -3,8 +3,11 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 )
 
 const (
@@ -17,22 +20,127 @@ const (
 	LevelNotice                //Reports conditions that are no errors but may require special handling.
 	LevelInfo                  //Reports normal user information about expected conditions.
 	LevelDebug                 //Reports information that is only interesting for debugging or development.
+	LevelTrace                 //Reports very verbose information like the steps of an algorithm or the data passed around.
 )
 
+// LevelAudit reports security relevant actions like logins or permission changes. It is more severe than
+// LevelPanic, so its records pass every Logger. They are neither sampled nor deduplicated, see Logger.Audit.
+const LevelAudit Level = -1
+
 // Represents a loglevel.
 type Level int
 
+// customLevels holds the loglevels registered by RegisterLevel.
+var customLevels struct {
+	sync.RWMutex
+	names map[Level]string
+}
+
 // Panics if the loglevel does not exist.
 func assertLoglevel(lvl Level) {
-	if lvl < LevelPanic || lvl > LevelDebug {
-		panic(fmt.Sprintf("Log level %d is not defined", lvl))
+	if err := checkLoglevel(lvl); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Returns an error if the loglevel does not exist.
+func checkLoglevel(lvl Level) error {
+	if lvl >= LevelPanic && lvl <= LevelTrace || lvl == LevelAudit {
+		return nil
+	}
+	if _, ok := customLevelName(lvl); !ok {
+		return fmt.Errorf("Log level %d is not defined", lvl)
+	}
+	return nil
+}
+
+// RegisterLevel defines an additional loglevel with the given value and name. A value greater than LevelTrace
+// is less severe than LevelTrace, a negative value is more severe than LevelPanic, so records
+// of that level pass every Logger, e.g. an audit level. The name is used as string representation and is accepted
+// by ParseLevel. RegisterLevel should be called during the initialization of a program. Passing a value or name that
+// is already in use, the value of LevelAudit, a value between LevelInvalid and LevelTrace or an empty name will cause a panic.
+func RegisterLevel(value Level, name string) {
+	if value >= LevelInvalid && value <= LevelTrace || value == LevelAudit {
+		panic("Programming error: logger.RegisterLevel: Passed the value of a predefined loglevel")
+	}
+	if len(name) < 1 {
+		panic("Programming error: logger.RegisterLevel: Passed empty string as name")
+	}
+	if _, err := ParseLevel(name); err == nil {
+		panic(fmt.Sprintf("Programming error: logger.RegisterLevel: Passed the name %q of an existing loglevel", name))
 	}
+	customLevels.Lock()
+	defer customLevels.Unlock()
+	if _, ok := customLevels.names[value]; ok {
+		panic(fmt.Sprintf("Programming error: logger.RegisterLevel: Passed the value %d of an existing loglevel", value))
+	}
+	if customLevels.names == nil {
+		customLevels.names = make(map[Level]string)
+	}
+	customLevels.names[value] = name
+}
+
+// customLevelName returns the name of the registered loglevel lvl. ok is false if lvl was not registered.
+func customLevelName(lvl Level) (name string, ok bool) {
+	customLevels.RLock()
+	defer customLevels.RUnlock()
+	name, ok = customLevels.names[lvl]
+	return name, ok
+}
+
+// levelAliases maps common loglevel names of other logging libraries and syslog and the short names
+// of LevelNameShort to loglevels.
+var levelAliases = map[string]Level{
+	"emerg":         LevelPanic,
+	"emergency":     LevelPanic,
+	"fatal":         LevelPanic,
+	"pnc":           LevelPanic,
+	"alr":           LevelAlert,
+	"crit":          LevelCritical,
+	"crt":           LevelCritical,
+	"err":           LevelError,
+	"warn":          LevelWarning,
+	"wrn":           LevelWarning,
+	"ntc":           LevelNotice,
+	"inf":           LevelInfo,
+	"information":   LevelInfo,
+	"informational": LevelInfo,
+	"dbg":           LevelDebug,
+	"trc":           LevelTrace,
+	"aud":           LevelAudit,
 }
 
-// Tries to associate the input string with a specific loglevel.
-// Returns that loglevel on success, on failure LevelInvalid and an
-// error is returned.
+// Tries to associate the input string with a specific loglevel, ignoring case and surrounding whitespace.
+// Besides the names of the loglevels, ParseLevel accepts common aliases like "warn", "err", "crit" or "fatal"
+// and the syslog severities "0" (LevelPanic) to "7" (LevelDebug). Returns that loglevel on success,
+// on failure LevelInvalid and an error is returned.
 func ParseLevel(input string) (Level, error) {
+	trimmed := strings.TrimSpace(input)
+	if lvl, err := ParseLevelStrict(trimmed); err == nil {
+		return lvl, nil
+	}
+	if lvl, ok := levelAliases[strings.ToLower(trimmed)]; ok {
+		return lvl, nil
+	}
+	if len(trimmed) == 1 {
+		if lvl, ok := severityLevel(int(trimmed[0]) - '0'); ok {
+			return lvl, nil
+		}
+	}
+	return LevelInvalid, fmt.Errorf("Input sequence %q cannot be associated with a defined loglevel", input)
+}
+
+// severityLevel returns the loglevel of the syslog severity, from 0 (LevelPanic) to 7 (LevelDebug).
+// It returns false if severity is out of range.
+func severityLevel(severity int) (Level, bool) {
+	if severity < 0 || severity > 7 {
+		return LevelInvalid, false
+	}
+	return LevelPanic + Level(severity), true
+}
+
+// ParseLevelStrict is like ParseLevel, but only accepts the names of the loglevels, ignoring case.
+func ParseLevelStrict(input string) (Level, error) {
 	switch lvl := strings.ToLower(input); lvl {
 	case "panic":
 		return LevelPanic, nil
@@ -50,6 +158,17 @@ func ParseLevel(input string) (Level, error) {
 		return LevelInfo, nil
 	case "debug":
 		return LevelDebug, nil
+	case "trace":
+		return LevelTrace, nil
+	case "audit":
+		return LevelAudit, nil
+	}
+	customLevels.RLock()
+	defer customLevels.RUnlock()
+	for lvl, name := range customLevels.names {
+		if strings.EqualFold(name, input) {
+			return lvl, nil
+		}
 	}
 	return LevelInvalid, fmt.Errorf("Input sequence %q cannot be associated with a defined loglevel", input)
 }
@@ -74,16 +193,95 @@ func (r Level) String() string {
 		return "Info"
 	case LevelDebug:
 		return "Debug"
+	case LevelTrace:
+		return "Trace"
+	case LevelAudit:
+		return "Audit"
+	}
+	if name, ok := customLevelName(r); ok {
+		return name
 	}
 	return "Undefined"
 }
 
+// SyslogSeverity returns the severity RFC 5424 assigns to the Level, ranging from 0 (Emergency) for LevelPanic
+// to 7 (Debug) for LevelDebug and LevelTrace. LevelAudit maps to 5 (Notice). Registered loglevels more severe than
+// LevelPanic map to 0, less severe ones map to 7. Calling it on an invalid loglevel will cause a panic.
+func (r Level) SyslogSeverity() int {
+	assertLoglevel(r)
+	switch {
+	case r == LevelAudit:
+		return 5
+	case r < LevelPanic:
+		return 0
+	case r > LevelDebug:
+		return 7
+	}
+	return int(r - LevelPanic)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the string representation of the Level,
+// or an error if the Level is not defined.
+func (r Level) MarshalText() ([]byte, error) {
+	if err := checkLoglevel(r); err != nil {
+		return nil, err
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all input ParseLevel accepts.
+func (r *Level) UnmarshalText(text []byte) error {
+	lvl, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*r = lvl
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The Level is encoded as JSON string holding its string representation.
+func (r Level) MarshalJSON() ([]byte, error) {
+	text, err := r.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON string that ParseLevel accepts
+// as well as a JSON number holding a syslog severity like ParseLevel does, so 1 and "1" both decode to LevelAlert.
+func (r *Level) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return r.UnmarshalText([]byte(text))
+	}
+	var num int
+	if err := json.Unmarshal(bytes.TrimSpace(data), &num); err != nil {
+		return fmt.Errorf("Input sequence %q cannot be associated with a defined loglevel", data)
+	}
+	lvl, ok := severityLevel(num)
+	if !ok {
+		return fmt.Errorf("Input sequence %q cannot be associated with a defined loglevel", data)
+	}
+	*r = lvl
+	return nil
+}
+
 // Loglevels returns map with the string representations of all
-// available loglevels.
+// available loglevels, including the registered ones.
 func Loglevels() map[Level]string {
 	levels := make(map[Level]string)
-	for lvl := LevelPanic; lvl <= LevelDebug; lvl++ {
+	for lvl := LevelPanic; lvl <= LevelTrace; lvl++ {
 		levels[lvl] = lvl.String()
 	}
+	levels[LevelAudit] = LevelAudit.String()
+	customLevels.RLock()
+	defer customLevels.RUnlock()
+	for lvl, name := range customLevels.names {
+		levels[lvl] = name
+	}
 	return levels
 }
@@ -0,0 +1,132 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLevelJSON(t *testing.T) {
+	type config struct {
+		Level Level `json:"level"`
+	}
+	for lvl := LevelPanic; lvl <= LevelTrace; lvl++ {
+		b, err := json.Marshal(&config{Level: lvl})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg := new(config)
+		if err := json.Unmarshal(b, cfg); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Level != lvl {
+			t.Errorf("Level %s did not survive the round trip through %s", lvl, b)
+		}
+	}
+	cfg := new(config)
+	if err := json.Unmarshal([]byte(`{"level":4}`), cfg); err != nil || cfg.Level != LevelWarning {
+		t.Errorf("Expected level %s, got level %s and error %v", LevelWarning, cfg.Level, err)
+	}
+	for i := 0; i <= 7; i++ {
+		text, num := new(config), new(config)
+		if err := json.Unmarshal([]byte(fmt.Sprintf(`{"level":"%d"}`, i)), text); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(fmt.Sprintf(`{"level":%d}`, i)), num); err != nil {
+			t.Fatal(err)
+		}
+		if text.Level != num.Level {
+			t.Errorf("Severity %d: \"%d\" decoded to %s, %d decoded to %s", i, i, text.Level, i, num.Level)
+		}
+	}
+	for _, input := range []string{`{"level":"verbose"}`, `{"level":12}`, `{"level":-1}`, `{"level":true}`} {
+		if err := json.Unmarshal([]byte(input), cfg); err == nil {
+			t.Errorf("Expected an error for input %s", input)
+		}
+	}
+	if _, err := json.Marshal(LevelInvalid); err == nil {
+		t.Error("Expected an error when marshaling an invalid level")
+	}
+}
+
+func TestLevelText(t *testing.T) {
+	var lvl Level
+	if err := lvl.UnmarshalText([]byte("WARNING")); err != nil || lvl != LevelWarning {
+		t.Errorf("Expected level %s, got level %s and error %v", LevelWarning, lvl, err)
+	}
+	text, err := lvl.MarshalText()
+	if err != nil || string(text) != "Warning" {
+		t.Errorf("Expected %q, got %q and error %v", "Warning", text, err)
+	}
+}
+
+func TestRegisterLevel(t *testing.T) {
+	const (
+		levelSecurity Level = -100
+		levelChatter  Level = 100
+	)
+	RegisterLevel(levelSecurity, "Security")
+	RegisterLevel(levelChatter, "Chatter")
+	if lvl, err := ParseLevel("chatter"); err != nil || lvl != levelChatter {
+		t.Errorf("Expected level %s, got level %s and error %v", levelChatter, lvl, err)
+	}
+	if names := Loglevels(); names[levelSecurity] != "Security" || names[levelChatter] != "Chatter" {
+		t.Errorf("Registered levels are missing in %v", names)
+	}
+	b := new(strings.Builder)
+	l := New(b, LevelPanic, loglevelDelimiter)
+	l.Println(levelSecurity, "login")
+	l.Println(levelChatter, "filtered")
+	l.SetLevel(levelChatter)
+	l.Println(levelChatter, "chatty")
+	expected := "[Security] - login\n[Chatter] - chatty\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	if levelSecurity.SyslogSeverity() != 0 || levelChatter.SyslogSeverity() != 7 {
+		t.Errorf("Unexpected syslog severities %d and %d", levelSecurity.SyslogSeverity(), levelChatter.SyslogSeverity())
+	}
+	for _, reg := range []struct {
+		value Level
+		name  string
+	}{{LevelInfo, "Other"}, {levelChatter, "Other"}, {101, "debug"}, {102, ""}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic when registering %d as %q", reg.value, reg.name)
+				}
+			}()
+			RegisterLevel(reg.value, reg.name)
+		}()
+	}
+}
+
+func TestParseLevelAliases(t *testing.T) {
+	tests := map[string]Level{
+		"warn":     LevelWarning,
+		"WARNING":  LevelWarning,
+		"err":      LevelError,
+		"crit":     LevelCritical,
+		"fatal":    LevelPanic,
+		" trace\n": LevelTrace,
+		"0":        LevelPanic,
+		"4":        LevelWarning,
+		"7":        LevelDebug,
+	}
+	for input, expected := range tests {
+		if lvl, err := ParseLevel(input); err != nil || lvl != expected {
+			t.Errorf("%q: Expected %s. Got %s, %v", input, expected, lvl, err)
+		}
+	}
+	for _, input := range []string{"8", "-1", "warn", " info"} {
+		if _, err := ParseLevelStrict(input); err == nil {
+			t.Errorf("%q: Expected an error in strict mode", input)
+		}
+	}
+	if lvl, err := ParseLevelStrict("Notice"); err != nil || lvl != LevelNotice {
+		t.Errorf("Expected %s. Got %s, %v", LevelNotice, lvl, err)
+	}
+}
@@ -0,0 +1,99 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// MultiWriter is an io.Writer that duplicates its writes to several writers. Unlike io.MultiWriter, a failing
+// writer does not keep the following writers from being written to. Use AddOutput to send records of different
+// loglevels to different writers instead.
+type MultiWriter struct {
+	writers []io.Writer
+}
+
+// MultiWriteError reports the writers of a MultiWriter that failed.
+type MultiWriteError struct {
+	Errs []error // Errors of the failed writers, in the order of the writers.
+}
+
+// NewMultiWriter returns a MultiWriter that writes to writers.
+func NewMultiWriter(writers ...io.Writer) *MultiWriter {
+	for _, w := range writers {
+		if w == nil {
+			panic("Programming error: logger.NewMultiWriter: Passed nil as writer")
+		}
+	}
+	return &MultiWriter{writers: append([]io.Writer(nil), writers...)}
+}
+
+// Close closes all writers that implement io.Closer, except os.Stdout and os.Stderr.
+func (m *MultiWriter) Close() error {
+	var errs []error
+	for _, w := range m.writers {
+		if c, ok := w.(io.Closer); ok && w != os.Stdout && w != os.Stderr {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return newMultiWriteError(errs)
+}
+
+// Flush flushes all writers that have a Flush method.
+func (m *MultiWriter) Flush() error {
+	var errs []error
+	for _, w := range m.writers {
+		if f, ok := w.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return newMultiWriteError(errs)
+}
+
+// Write writes p to all writers. If at least one writer succeeds, len(p) is returned, so a Logger does not
+// apply its error policy and write p again to the writers that succeeded. Failures are returned as *MultiWriteError,
+// which a Logger passes to its error handler.
+func (m *MultiWriter) Write(p []byte) (int, error) {
+	var errs []error
+	for _, w := range m.writers {
+		n, err := w.Write(p)
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 && len(errs) == len(m.writers) {
+		return 0, newMultiWriteError(errs)
+	}
+	return len(p), newMultiWriteError(errs)
+}
+
+// newMultiWriteError returns a *MultiWriteError holding errs, nil if errs is empty.
+func newMultiWriteError(errs []error) error {
+	if len(errs) < 1 {
+		return nil
+	}
+	return &MultiWriteError{Errs: errs}
+}
+
+func (e *MultiWriteError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d writers failed: %s", len(e.Errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed writers.
+func (e *MultiWriteError) Unwrap() []error {
+	return e.Errs
+}
@@ -0,0 +1,30 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMultiWriter(t *testing.T) {
+	first := new(strings.Builder)
+	second := new(strings.Builder)
+	var errs []error
+	l := New(NewMultiWriter(first, failingWriter{}, second), LevelInfo, loglevelDelimiter)
+	l.SetErrorHandler(func(err error) { errs = append(errs, err) })
+	l.SetErrorPolicy(NewRetryPolicy(3, 0))
+	l.Info("message")
+	expected := "[Info] - message\n"
+	if first.String() != expected || second.String() != expected {
+		t.Errorf("Expected %q in both writers. Got %q and %q", expected, first.String(), second.String())
+	}
+	var merr *MultiWriteError
+	if len(errs) != 1 || !errors.As(errs[0], &merr) || len(merr.Errs) != 1 {
+		t.Errorf("Expected a single MultiWriteError. Got %v", errs)
+	}
+	if _, err := NewMultiWriter(failingWriter{}, failingWriter{}).Write([]byte("x")); err == nil {
+		t.Error("Expected an error if all writers fail")
+	}
+}
@@ -0,0 +1,234 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	BackpressureBlock      Backpressure = iota //Block the writer until there is space in the buffer.
+	BackpressureDropNewest                     //Drop the record that is being written.
+	BackpressureDropOldest                     //Drop the oldest buffered record to make space.
+)
+
+// Default values of NetOptions.
+const (
+	defaultNetBufferSize        = 1024
+	defaultNetDialTimeout       = 5 * time.Second
+	defaultNetWriteTimeout      = 5 * time.Second
+	defaultNetReconnectDelay    = 100 * time.Millisecond
+	defaultNetMaxReconnectDelay = 30 * time.Second
+)
+
+// Represents what a NetWriter does if its buffer is full.
+type Backpressure int
+
+// String returns the string representation of a Backpressure. If the Backpressure is
+// not defined, String returns "Undefined".
+func (bp Backpressure) String() string {
+	switch bp {
+	case BackpressureBlock:
+		return "Block"
+	case BackpressureDropNewest:
+		return "DropNewest"
+	case BackpressureDropOldest:
+		return "DropOldest"
+	}
+	return "Undefined"
+}
+
+// NetOptions configures a NetWriter. Zero values are replaced by sensible defaults.
+type NetOptions struct {
+	BufferSize        int           // Number of records buffered while they are sent or the connection is down, defaults to 1024.
+	DialTimeout       time.Duration // Timeout for establishing a connection, defaults to 5s.
+	WriteTimeout      time.Duration // Timeout for sending a record, defaults to 5s.
+	ReconnectDelay    time.Duration // Delay before the first reconnection attempt, it doubles with every failed attempt. Defaults to 100ms.
+	MaxReconnectDelay time.Duration // Upper limit of the reconnection delay, defaults to 30s.
+	Backpressure      Backpressure  // What to do if the buffer is full, defaults to BackpressureBlock.
+}
+
+// NetWriter is an io.WriteCloser that sends records to a remote collector like Logstash over a network
+// connection. Records are buffered and sent by a background goroutine, which reconnects automatically
+// if the connection breaks. A record that could not be sent is sent again after reconnecting.
+// Each record is sent exactly as written, for TCP the Logger's newline terminates the record,
+// for UDP every record is sent as a datagram. A NetWriter can be used by multiple goroutines.
+type NetWriter struct {
+	network  string
+	addr     string
+	opts     NetOptions
+	dial     func(network, addr string, timeout time.Duration) (net.Conn, error)
+	mu       sync.RWMutex // Protects closed and guards buffer against being closed while sending.
+	closed   bool
+	buffer   chan []byte
+	done     chan struct{}
+	dropped  atomic.Uint64
+	stop     chan struct{} // Closed by Close to abort reconnection attempts.
+	stopOnce sync.Once
+}
+
+// NewNetWriter returns a NetWriter that sends records to addr on the named network, e.g. "tcp" or "udp".
+// The connection is established in the background, NewNetWriter only fails if the options are invalid.
+func NewNetWriter(network, addr string, opts NetOptions) (*NetWriter, error) {
+	return newNetWriter(network, addr, opts, net.DialTimeout)
+}
+
+// newNetWriter returns a NetWriter that uses dial to establish its connections.
+func newNetWriter(network, addr string, opts NetOptions, dial func(network, addr string, timeout time.Duration) (net.Conn, error)) (*NetWriter, error) {
+	if opts.BufferSize < 0 || opts.DialTimeout < 0 || opts.WriteTimeout < 0 || opts.ReconnectDelay < 0 || opts.MaxReconnectDelay < 0 {
+		return nil, errors.New("Network writer options must not be negative")
+	}
+	if opts.Backpressure < BackpressureBlock || opts.Backpressure > BackpressureDropOldest {
+		return nil, fmt.Errorf("Backpressure policy %d is not defined", opts.Backpressure)
+	}
+	setDefault(&opts.BufferSize, defaultNetBufferSize)
+	setDefault(&opts.DialTimeout, defaultNetDialTimeout)
+	setDefault(&opts.WriteTimeout, defaultNetWriteTimeout)
+	setDefault(&opts.ReconnectDelay, defaultNetReconnectDelay)
+	setDefault(&opts.MaxReconnectDelay, defaultNetMaxReconnectDelay)
+	w := &NetWriter{
+		network: network,
+		addr:    addr,
+		opts:    opts,
+		dial:    dial,
+		buffer:  make(chan []byte, opts.BufferSize),
+		done:    make(chan struct{}),
+		stop:    make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// setDefault sets *v to def if *v is the zero value.
+func setDefault[T comparable](v *T, def T) {
+	var zero T
+	if *v == zero {
+		*v = def
+	}
+}
+
+// Close sends the buffered records and closes the connection. If the connection is down, the remaining records
+// are dropped instead of waiting for a reconnection. Writing to a closed NetWriter returns ErrClosed.
+func (w *NetWriter) Close() error {
+	// Stopping the reconnection attempts first unblocks writers that wait for space in the buffer.
+	w.stopOnce.Do(func() { close(w.stop) })
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.buffer)
+	}
+	w.mu.Unlock()
+	<-w.done
+	return nil
+}
+
+// Dropped returns the number of records that have been dropped because the buffer was full or the
+// NetWriter was closed while the connection was down.
+func (w *NetWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Write buffers a copy of p for sending. If the buffer is full, the NetWriter's backpressure policy applies.
+// Write reports p as written even if it was dropped, use Dropped to monitor the losses.
+func (w *NetWriter) Write(p []byte) (n int, err error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+	b := append([]byte(nil), p...)
+	switch w.opts.Backpressure {
+	case BackpressureDropNewest:
+		select {
+		case w.buffer <- b:
+		default:
+			w.dropped.Add(1)
+		}
+	case BackpressureDropOldest:
+		for {
+			select {
+			case w.buffer <- b:
+				return len(p), nil
+			default:
+			}
+			select {
+			case <-w.buffer:
+				w.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		w.buffer <- b
+	}
+	return len(p), nil
+}
+
+// run sends the buffered records until the NetWriter is closed.
+func (w *NetWriter) run() {
+	defer close(w.done)
+	var conn net.Conn
+	delay := w.opts.ReconnectDelay
+	for b := range w.buffer {
+		for {
+			if conn == nil {
+				if w.stopped() {
+					w.dropped.Add(1)
+					break
+				}
+				var err error
+				if conn, err = w.dial(w.network, w.addr, w.opts.DialTimeout); err != nil {
+					conn = nil
+					if !w.sleep(delay) {
+						w.dropped.Add(1)
+						break
+					}
+					delay = min(delay*2, w.opts.MaxReconnectDelay)
+					continue
+				}
+				delay = w.opts.ReconnectDelay
+			}
+			if !connAlive(conn) {
+				conn.Close()
+				conn = nil
+				continue
+			}
+			conn.SetWriteDeadline(time.Now().Add(w.opts.WriteTimeout))
+			if _, err := conn.Write(b); err != nil {
+				conn.Close()
+				conn = nil
+				continue
+			}
+			break
+		}
+	}
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+// stopped returns true if the NetWriter has been closed.
+func (w *NetWriter) stopped() bool {
+	select {
+	case <-w.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleep waits for d. It returns false without waiting if the NetWriter has been closed.
+func (w *NetWriter) sleep(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-w.stop:
+		return false
+	}
+}
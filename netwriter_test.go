@@ -0,0 +1,104 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNetWriterReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	lines := make(chan string, 16)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			// Every connection receives a single record, forcing the writer to reconnect.
+			scanner := bufio.NewScanner(conn)
+			scanner.Scan()
+			conn.Close()
+			lines <- scanner.Text()
+		}
+	}()
+	w, err := NewNetWriter("tcp", ln.Addr().String(), NetOptions{ReconnectDelay: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	l := New(w, LevelDebug, loglevelDelimiter)
+	for _, msg := range []string{"first", "second"} {
+		l.Info(msg)
+		select {
+		case line := <-lines:
+			if expect := "[Info] - " + msg; line != expect {
+				t.Errorf("Expected %q. Got %q", expect, line)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Record %q was not received", msg)
+		}
+	}
+}
+
+func TestNetWriterDropNewest(t *testing.T) {
+	refuse := func(network, addr string, timeout time.Duration) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+	w, err := newNetWriter("tcp", "unreachable", NetOptions{BufferSize: 2, Backpressure: BackpressureDropNewest}, refuse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := w.Write([]byte("record\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+	if w.Dropped() != 10 {
+		t.Errorf("Expected 10 dropped records, got %d", w.Dropped())
+	}
+	if _, err := w.Write([]byte("record\n")); err != ErrClosed {
+		t.Errorf("Expected %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestNetWriterCloseBlackhole(t *testing.T) {
+	// A blackholed host never answers, every dial waits for the whole timeout.
+	var dials atomic.Int32
+	blackhole := func(network, addr string, timeout time.Duration) (net.Conn, error) {
+		dials.Add(1)
+		time.Sleep(timeout)
+		return nil, errors.New("i/o timeout")
+	}
+	opts := NetOptions{BufferSize: 20, DialTimeout: 50 * time.Millisecond, ReconnectDelay: time.Hour}
+	w, err := newNetWriter("tcp", "blackhole", opts, blackhole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		if _, err := w.Write([]byte("record\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now()
+	w.Close()
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Close waited %s for reconnections", d)
+	}
+	if n := dials.Load(); n > 1 {
+		t.Errorf("Expected at most 1 dial, got %d", n)
+	}
+	if w.Dropped() != 20 {
+		t.Errorf("Expected 20 dropped records, got %d", w.Dropped())
+	}
+}
@@ -0,0 +1,144 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// throttle holds the time a record was last written for the keys of PrintOnce and PrintEvery.
+// It has its own lock, so hooks and writers can call these methods while the Logger's lock is held.
+type throttle struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// allow returns true and remembers now as the time of key if key has not been seen before or,
+// if interval is greater than 0, its last time lies at least interval before now.
+func (t *throttle) allow(key string, interval time.Duration, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if last, ok := t.last[key]; ok && (interval < 1 || now.Sub(last) < interval) {
+		return false
+	}
+	if t.last == nil {
+		t.last = make(map[string]time.Time)
+	}
+	t.last[key] = now
+	return true
+}
+
+// AlertOnce sends a message of loglevel LevelAlert to the Logger once per key, see PrintOnce.
+func (l *Logger) AlertOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelAlert, key, v...)
+}
+
+// AlertEvery sends a message of loglevel LevelAlert to the Logger at most once per interval and key, see PrintEvery.
+func (l *Logger) AlertEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelAlert, key, interval, v...)
+}
+
+// CriticalOnce sends a message of loglevel LevelCritical to the Logger once per key, see PrintOnce.
+func (l *Logger) CriticalOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelCritical, key, v...)
+}
+
+// CriticalEvery sends a message of loglevel LevelCritical to the Logger at most once per interval and key, see PrintEvery.
+func (l *Logger) CriticalEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelCritical, key, interval, v...)
+}
+
+// DebugOnce sends a message of loglevel LevelDebug to the Logger once per key, see PrintOnce.
+func (l *Logger) DebugOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelDebug, key, v...)
+}
+
+// DebugEvery sends a message of loglevel LevelDebug to the Logger at most once per interval and key, see PrintEvery.
+func (l *Logger) DebugEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelDebug, key, interval, v...)
+}
+
+// ErrorOnce sends a message of loglevel LevelError to the Logger once per key, see PrintOnce.
+func (l *Logger) ErrorOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelError, key, v...)
+}
+
+// ErrorEvery sends a message of loglevel LevelError to the Logger at most once per interval and key, see PrintEvery.
+func (l *Logger) ErrorEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelError, key, interval, v...)
+}
+
+// InfoOnce sends a message of loglevel LevelInfo to the Logger once per key, see PrintOnce.
+func (l *Logger) InfoOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelInfo, key, v...)
+}
+
+// InfoEvery sends a message of loglevel LevelInfo to the Logger at most once per interval and key, see PrintEvery.
+func (l *Logger) InfoEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelInfo, key, interval, v...)
+}
+
+// NoticeOnce sends a message of loglevel LevelNotice to the Logger once per key, see PrintOnce.
+func (l *Logger) NoticeOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelNotice, key, v...)
+}
+
+// NoticeEvery sends a message of loglevel LevelNotice to the Logger at most once per interval and key, see PrintEvery.
+func (l *Logger) NoticeEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelNotice, key, interval, v...)
+}
+
+// PanicOnce sends a message of loglevel LevelPanic to the Logger once per key, see PrintOnce.
+// Please note that it does NOT call panic()!
+func (l *Logger) PanicOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelPanic, key, v...)
+}
+
+// PanicEvery sends a message of loglevel LevelPanic to the Logger at most once per interval and key, see PrintEvery.
+// Please note that it does NOT call panic()!
+func (l *Logger) PanicEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelPanic, key, interval, v...)
+}
+
+// PrintOnce writes the log message like Println, but only the first time it is called with key, so a message
+// in a loop does not flood the output. Calls while the loglevel is not enabled do not count. The keys are shared by
+// all Loggers derived from the same Logger and are kept for its lifetime, so key should be a constant like "cache-miss".
+func (l *Logger) PrintOnce(level Level, key string, v ...any) (n int, err error) {
+	if !l.Enabled(level) || !l.throttle.allow(key, 0, l.now()) {
+		return 0, nil
+	}
+	return l.Output(Record{Level: level, Message: sprint(resolveLazy(v))})
+}
+
+// PrintEvery writes the log message like Println, but at most once per interval for each key, see PrintOnce.
+// The interval is measured by the Logger's clock, see SetClock. Passing a non-positive interval will cause a panic.
+func (l *Logger) PrintEvery(level Level, key string, interval time.Duration, v ...any) (n int, err error) {
+	if interval < 1 {
+		panic("Programming error: (l *Logger) PrintEvery(): Passed non-positive interval")
+	}
+	if !l.Enabled(level) || !l.throttle.allow(key, interval, l.now()) {
+		return 0, nil
+	}
+	return l.Output(Record{Level: level, Message: sprint(resolveLazy(v))})
+}
+
+// TraceOnce sends a message of loglevel LevelTrace to the Logger once per key, see PrintOnce.
+func (l *Logger) TraceOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelTrace, key, v...)
+}
+
+// TraceEvery sends a message of loglevel LevelTrace to the Logger at most once per interval and key, see PrintEvery.
+func (l *Logger) TraceEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelTrace, key, interval, v...)
+}
+
+// WarningOnce sends a message of loglevel LevelWarning to the Logger once per key, see PrintOnce.
+func (l *Logger) WarningOnce(key string, v ...any) (n int, err error) {
+	return l.PrintOnce(LevelWarning, key, v...)
+}
+
+// WarningEvery sends a message of loglevel LevelWarning to the Logger at most once per interval and key, see PrintEvery.
+func (l *Logger) WarningEvery(key string, interval time.Duration, v ...any) (n int, err error) {
+	return l.PrintEvery(LevelWarning, key, interval, v...)
+}
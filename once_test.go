@@ -0,0 +1,42 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintOnce(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	for i := 0; i < 3; i++ {
+		l.DebugOnce("loop", "disabled")
+		l.InfoOnce("loop", "iteration ", i)
+		l.WithPrefix("child").WarningOnce("child", "shared")
+	}
+	l.SetLevel(LevelDebug)
+	l.DebugOnce("loop", "not written")
+	l.DebugOnce("debug", "written")
+	expected := "[Info] - iteration 0\n[Warning] - child: shared\n[Debug] - written\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestPrintEvery(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	l.SetClock(func() time.Time { return now })
+	l.ErrorEvery("retry", time.Minute, "failed")
+	l.ErrorEvery("retry", time.Minute, "suppressed")
+	l.ErrorEvery("other", time.Minute, "other key")
+	now = now.Add(time.Minute)
+	l.ErrorEvery("retry", time.Minute, "failed again")
+	expected := "[Error] - failed\n[Error] - other key\n[Error] - failed again\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,180 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+// Options holds the settings of a Logger that can be changed at runtime, see (l *Logger) Options and (l *Logger) Apply.
+// Like Config, it can be encoded to and decoded from JSON, TOML or YAML, so tools can display and patch the settings
+// of a running program. Writers, hooks, sinks and other settings that hold functions or objects are not part of Options.
+type Options struct {
+	Level           Level          `json:"level" toml:"level"`                                           // Loglevel, see SetLevel.
+	Format          Format         `json:"format" toml:"format"`                                         // Output format, see SetFormat.
+	TimeFormat      string         `json:"time_format" toml:"time_format"`                               // Layout of the timestamps, see SetTimeFormat.
+	TimeStyle       TimeStyle      `json:"time_style" toml:"time_style"`                                 // Rendering of the timestamps, see SetTimeStyle.
+	Color           ColorMode      `json:"color" toml:"color"`                                           // Colorization, see SetColor.
+	Secrets         SecretPolicy   `json:"secrets" toml:"secrets"`                                       // Treatment of secret fields, see SetSecretPolicy.
+	Layout          []Segment      `json:"layout,omitempty" toml:"layout,omitempty"`                     // Segments of FormatText, empty for the default layout, see SetLayout.
+	Template        string         `json:"template,omitempty" toml:"template,omitempty"`                 // Record template, empty to use the layout, see SetTemplate.
+	PadLevel        bool           `json:"pad_level" toml:"pad_level"`                                   // See SetPadLevel.
+	LevelNames      LevelNameStyle `json:"level_names" toml:"level_names"`                               // See SetLevelNameStyle.
+	EscapeDelimiter bool           `json:"escape_delimiter" toml:"escape_delimiter"`                     // See SetEscapeDelimiter.
+	IncludeHostname bool           `json:"include_hostname" toml:"include_hostname"`                     // See SetIncludeHostname.
+	IncludePID      bool           `json:"include_pid" toml:"include_pid"`                               // See SetIncludePID.
+	ReportCaller    bool           `json:"report_caller" toml:"report_caller"`                           // See SetReportCaller.
+	CallerSkip      int            `json:"caller_skip" toml:"caller_skip"`                               // See SetCallerSkip.
+	StacktraceLevel *Level         `json:"stacktrace_level,omitempty" toml:"stacktrace_level,omitempty"` // nil disables stack traces, see SetStacktraceLevel.
+	MaxLength       int            `json:"max_length" toml:"max_length"`                                 // 0 means unlimited, see SetMaxLength.
+	LengthPolicy    LengthPolicy   `json:"length_policy" toml:"length_policy"`                           // See SetMaxLength.
+	DedupWindow     string         `json:"dedup_window,omitempty" toml:"dedup_window,omitempty"`         // Duration like "5s", empty disables deduplication, see SetDedupWindow.
+	WriteTimeout    string         `json:"write_timeout,omitempty" toml:"write_timeout,omitempty"`       // Duration like "1s", empty disables the timeout, see SetWriteTimeout.
+	Deterministic   bool           `json:"deterministic" toml:"deterministic"`                           // Reproducible output for tests, see SetDeterministic.
+}
+
+// Options returns a snapshot of the Logger's settings.
+func (l *Logger) Options() Options {
+	defer l.runlock(l.rlock())
+	opts := Options{
+		Level:           Level(l.level.Load()),
+		Format:          l.format,
+		TimeFormat:      l.timeFormat,
+		TimeStyle:       l.timeStyle,
+		Color:           l.color,
+		Secrets:         l.secretPolicy,
+		Layout:          append([]Segment(nil), l.layout...),
+		PadLevel:        l.padLevel,
+		LevelNames:      l.levelNameStyle,
+		EscapeDelimiter: l.escapeDelimiter,
+		IncludeHostname: l.includeHostname,
+		IncludePID:      l.includePID,
+		ReportCaller:    l.reportCaller,
+		CallerSkip:      l.callerSkip,
+		MaxLength:       l.maxLength,
+		LengthPolicy:    l.lengthPolicy,
+		Deterministic:   l.deterministic.Load(),
+	}
+	if l.template != nil {
+		opts.Template = l.template.source
+	}
+	if l.stacktraceLevel != LevelInvalid {
+		level := l.stacktraceLevel
+		opts.StacktraceLevel = &level
+	}
+	if l.dedup != nil {
+		opts.DedupWindow = l.dedup.window.String()
+	}
+	if l.writeTimeout > 0 {
+		opts.WriteTimeout = l.writeTimeout.String()
+	}
+	return opts
+}
+
+// Apply changes the Logger's settings to opts at once, records are either written with the old or with the new settings.
+// Options are usually obtained by Options and modified before they are applied. If opts contains an invalid setting,
+// Apply returns an error and the Logger's settings remain unchanged. Called from within the Logger while it writes
+// records, e.g. by a Hook, Apply returns ErrReentrancy.
+func (l *Logger) Apply(opts Options) error {
+	if l.reentrant() {
+		return ErrReentrancy
+	}
+	errs := []error{
+		checkLoglevel(opts.Level),
+		checkFormat(opts.Format),
+		checkTimeStyle(opts.TimeStyle),
+		checkColorMode(opts.Color),
+		checkSecretPolicy(opts.Secrets),
+		checkLevelNameStyle(opts.LevelNames),
+		checkLengthPolicy(opts.LengthPolicy),
+	}
+	for _, seg := range opts.Layout {
+		errs = append(errs, checkSegment(seg))
+	}
+	if opts.StacktraceLevel != nil {
+		errs = append(errs, checkLoglevel(*opts.StacktraceLevel))
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	if opts.CallerSkip < 0 {
+		return fmt.Errorf("Caller skip %d is negative", opts.CallerSkip)
+	}
+	var tmpl *template
+	if len(opts.Template) > 0 {
+		var err error
+		if tmpl, err = parseTemplate(opts.Template); err != nil {
+			return err
+		}
+	}
+	dedupWindow, err := parseOptionDuration("Dedup window", opts.DedupWindow)
+	if err != nil {
+		return err
+	}
+	writeTimeout, err := parseOptionDuration("Write timeout", opts.WriteTimeout)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	old, listeners := Level(l.level.Swap(int32(opts.Level))), l.level.listeners
+	l.format = opts.Format
+	l.timeFormat = opts.TimeFormat
+	l.timeStyle = opts.TimeStyle
+	l.color = opts.Color
+	l.deterministic.Store(opts.Deterministic)
+	l.updateColorize()
+	l.secretPolicy = opts.Secrets
+	l.layout = nil
+	if len(opts.Layout) > 0 {
+		l.layout = append([]Segment(nil), opts.Layout...)
+	}
+	l.template = tmpl
+	l.padLevel = opts.PadLevel
+	l.levelNameStyle = opts.LevelNames
+	l.escapeDelimiter = opts.EscapeDelimiter
+	l.includeHostname = opts.IncludeHostname
+	l.includePID = opts.IncludePID
+	l.reportCaller = opts.ReportCaller
+	l.callerSkip = opts.CallerSkip
+	l.stacktraceLevel = LevelInvalid
+	if opts.StacktraceLevel != nil {
+		l.stacktraceLevel = *opts.StacktraceLevel
+	}
+	l.snapshotShards()
+	l.maxLength = max(opts.MaxLength, 0)
+	l.lengthPolicy = opts.LengthPolicy
+	l.writeTimeout = writeTimeout
+	var summary *Record
+	if l.dedup != nil && l.dedup.window != dedupWindow {
+		summary = l.repeatSummary()
+	}
+	if dedupWindow == 0 {
+		l.dedup = nil
+	} else if l.dedup == nil {
+		l.dedup = &dedup{window: dedupWindow}
+	} else {
+		l.dedup.window = dedupWindow
+	}
+	l.mu.Unlock()
+	l.emit(summary)
+	notifyLevelChange(listeners, old, opts.Level)
+	return nil
+}
+
+// parseOptionDuration parses the duration of the option name, an empty string means 0.
+func parseOptionDuration(name, s string) (time.Duration, error) {
+	if len(s) < 1 {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s %s is negative", name, s)
+	}
+	return d, nil
+}
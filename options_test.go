@@ -0,0 +1,76 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	l := New(new(strings.Builder), LevelWarning, loglevelDelimiter)
+	l.SetFormat(FormatJSON)
+	l.SetTimeStyle(TimeUTC)
+	l.SetLayout(SegmentMessage, SegmentLevel)
+	l.SetLevelNameStyle(LevelNameShort)
+	l.SetStacktraceLevel(LevelCritical)
+	l.SetMaxLength(100, LengthSplit)
+	l.SetDedupWindow(5 * time.Second)
+	opts := l.Options()
+	b, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"level":"Warning"`, `"time_style":"UTC"`, `"layout":["Message","Level"]`, `"stacktrace_level":"Critical"`, `"dedup_window":"5s"`} {
+		if !strings.Contains(string(b), s) {
+			t.Errorf("Expected %s in %s", s, b)
+		}
+	}
+	var decoded Options
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	c := New(new(strings.Builder), LevelInfo, loglevelDelimiter)
+	if err := c.Apply(decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c.Options(), opts) {
+		t.Errorf("Expected %+v. Got %+v", opts, c.Options())
+	}
+}
+
+func TestApply(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	var changes []string
+	l.OnLevelChange(func(old, new Level) {
+		changes = append(changes, old.String()+">"+new.String())
+	})
+	opts := l.Options()
+	opts.Level = LevelDebug
+	opts.LevelNames = LevelNameLetter
+	if err := l.Apply(opts); err != nil {
+		t.Fatal(err)
+	}
+	l.Debug("applied")
+	if b.String() != "[D] - applied\n" || len(changes) != 1 || changes[0] != "Info>Debug" {
+		t.Errorf("Unexpected output %q and level changes %v", b.String(), changes)
+	}
+	before := l.Options()
+	for _, invalid := range []Options{
+		{Level: LevelInvalid},
+		{Level: LevelInfo, Template: "{unknown}"},
+		{Level: LevelInfo, DedupWindow: "-1s"},
+		{Level: LevelInfo, Layout: []Segment{42}},
+	} {
+		if err := l.Apply(invalid); err == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+	if !reflect.DeepEqual(l.Options(), before) {
+		t.Errorf("Settings changed by invalid options: %+v", l.Options())
+	}
+}
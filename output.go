@@ -0,0 +1,153 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"reflect"
+)
+
+// output is an additional destination of a Logger.
+type output struct {
+	w      io.Writer
+	level  Level  // Least severe loglevel written to w.
+	format Format // Format of the records written to w.
+	own    bool   // Records are written in format instead of the Logger's format.
+}
+
+// AddOutput adds w as an additional destination for the Logger's records. w will only receive records
+// that are as severe as or more severe than minLevel, e.g. passing LevelWarning lets w receive warnings and
+// everything above. As all records have to pass the Logger's own loglevel first, minLevel can only narrow
+// down what w receives. Setting an invalid loglevel will cause a panic.
+func (l *Logger) AddOutput(w io.Writer, minLevel Level) {
+	if w == nil {
+		panic("Programming error: (l *Logger) AddOutput(): Passed nil as output writer")
+	}
+	assertLoglevel(minLevel)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.outputs = append(l.outputs, output{w: w, level: minLevel})
+}
+
+// AddFormatOutput adds w as an additional destination like AddOutput, but w receives the records in format
+// regardless of the Logger's format. Every record is rendered once per format from the same Record, e.g. to write
+// colored text to a terminal and JSON to a file:
+//
+//	l := logger.New(os.Stderr, logger.LevelInfo, " - ")
+//	l.SetColor(logger.ColorAuto)
+//	l.AddFormatOutput(file, logger.LevelDebug, logger.FormatJSON)
+//
+// Records written to w are not colorized. Setting an invalid loglevel or format will cause a panic.
+func (l *Logger) AddFormatOutput(w io.Writer, minLevel Level, format Format) {
+	if w == nil {
+		panic("Programming error: (l *Logger) AddFormatOutput(): Passed nil as output writer")
+	}
+	assertLoglevel(minLevel)
+	assertFormat(format)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.outputs = append(l.outputs, output{w: w, level: minLevel, format: format, own: true})
+}
+
+// SetLevelOutput routes records of the given level to w instead of the Logger's writer, e.g. to write
+// warnings and more severe records to os.Stderr while the Logger writes to os.Stdout. Outputs added by AddOutput
+// are not affected. Passing nil as w routes the level back to the Logger's writer. Passing an invalid loglevel will cause a panic.
+func (l *Logger) SetLevelOutput(level Level, w io.Writer) {
+	assertLoglevel(level)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if w == nil {
+		delete(l.levelOutputs, level)
+		return
+	}
+	if l.levelOutputs == nil {
+		l.levelOutputs = make(map[Level]io.Writer)
+	}
+	l.levelOutputs[level] = w
+}
+
+// writeOutputs writes the rendered record b to the Logger's writer or the writer set for level and to all additional outputs
+// that accept the level of rec. Outputs with a format of their own receive rec rendered in that format.
+// A failing destination does not keep the record from being written to the others, errors are
+// handled according to the Logger's error policy.
+// n is the number of bytes written to the first writer, err is the first error that occurred.
+// The caller must hold the Logger's lock.
+func (l *Logger) writeOutputs(rec *Record, b []byte) (n int, err error) {
+	dest := mainDestination
+	w, ok := l.levelOutputs[rec.Level]
+	if ok {
+		dest.level = rec.Level
+	} else {
+		w = l.out
+	}
+	n, err = l.writeTo(dest, w, b)
+	var encoded [FormatCSV + 1]*[]byte
+	for i, o := range l.outputs {
+		if rec.Level > o.level {
+			continue
+		}
+		ob := b
+		if o.own {
+			if encoded[o.format] == nil {
+				buf := getBuffer()
+				defer putBuffer(buf)
+				var eerr error
+				if *buf, eerr = l.encodePlain(*buf, rec, o.format); eerr != nil {
+					l.handleError(eerr)
+					if err == nil {
+						err = eerr
+					}
+					continue
+				}
+				encoded[o.format] = buf
+			}
+			ob = *encoded[o.format]
+		}
+		if _, oerr := l.writeTo(destination{output: i, level: LevelInvalid}, o.w, ob); oerr != nil && err == nil {
+			err = oerr
+		}
+	}
+	return n, err
+}
+
+// writers returns the distinct writers of the Logger: its writer, the writers set by SetLevelOutput and the
+// additional outputs. The caller must hold the Logger's lock.
+func (l *Logger) writers() []io.Writer {
+	var writers []io.Writer
+	add := func(w io.Writer) {
+		if w == nil {
+			return
+		}
+		comparable := reflect.TypeOf(w).Comparable()
+		for _, known := range writers {
+			if comparable && known == w {
+				return
+			}
+		}
+		writers = append(writers, w)
+	}
+	add(l.out)
+	for _, w := range l.levelOutputs {
+		add(w)
+	}
+	for _, o := range l.outputs {
+		add(o.w)
+	}
+	return writers
+}
+
+// flushWriters flushes all writers of the Logger that have a Flush method and returns the first error.
+// The caller must hold the Logger's lock.
+func (l *Logger) flushWriters() error {
+	var err error
+	for _, w := range l.writers() {
+		f, ok := w.(interface{ Flush() error })
+		if !ok {
+			continue
+		}
+		if ferr := f.Flush(); ferr != nil && err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
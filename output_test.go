@@ -0,0 +1,83 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestAddOutput(t *testing.T) {
+	all := new(strings.Builder)
+	severe := new(strings.Builder)
+	l := New(all, LevelInfo, loglevelDelimiter)
+	l.AddOutput(failingWriter{}, LevelDebug)
+	l.AddOutput(severe, LevelWarning)
+	l.Debug("debug")
+	l.Info("info")
+	if _, err := l.Warning("warning"); err == nil {
+		t.Error("Expected the error of the failing output")
+	}
+	l.Panic("panic")
+	expectAll := "[Info] - info\n[Warning] - warning\n[Panic] - panic\n"
+	if all.String() != expectAll {
+		t.Errorf("Expected %q. Got %q", expectAll, all.String())
+	}
+	expectSevere := "[Warning] - warning\n[Panic] - panic\n"
+	if severe.String() != expectSevere {
+		t.Errorf("Expected %q. Got %q", expectSevere, severe.String())
+	}
+}
+
+func TestSetLevelOutput(t *testing.T) {
+	stdout := new(strings.Builder)
+	stderr := new(strings.Builder)
+	l := New(stdout, LevelDebug, loglevelDelimiter)
+	for lvl := LevelPanic; lvl <= LevelWarning; lvl++ {
+		l.SetLevelOutput(lvl, stderr)
+	}
+	l.Info("info")
+	l.Warning("warning")
+	l.Panic("panic")
+	l.SetLevelOutput(LevelWarning, nil)
+	l.Warning("back")
+	if expect := "[Info] - info\n[Warning] - back\n"; stdout.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, stdout.String())
+	}
+	if expect := "[Warning] - warning\n[Panic] - panic\n"; stderr.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, stderr.String())
+	}
+}
+
+func TestAddFormatOutput(t *testing.T) {
+	console := new(strings.Builder)
+	file := new(strings.Builder)
+	csv := new(strings.Builder)
+	l := New(console, LevelInfo, loglevelDelimiter)
+	l.SetColor(ColorAlways)
+	l.SetClock(func() time.Time { return time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC) })
+	l.AddFormatOutput(file, LevelInfo, FormatJSON)
+	l.AddFormatOutput(csv, LevelError, FormatCSV)
+	l.InfoKV("started", "port", 80)
+	l.Error("failed")
+	expected := "[Info] - started - port=80\n" + ansiRed + "[Error]" + ansiReset + " - failed\n"
+	if got := console.String(); got != expected {
+		t.Errorf("Expected %q. Got %q", expected, got)
+	}
+	expected = `{"level":"Info","time":"2023-05-01T12:00:00Z","message":"started","fields":{"port":80}}` + "\n" +
+		`{"level":"Error","time":"2023-05-01T12:00:00Z","message":"failed"}` + "\n"
+	if got := file.String(); got != expected {
+		t.Errorf("Expected %q. Got %q", expected, got)
+	}
+	if got := csv.String(); !strings.HasSuffix(got, ",Error,,failed,\n") || strings.Count(got, "\n") != 1 {
+		t.Errorf("Unexpected CSV output %q", got)
+	}
+}
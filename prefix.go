@@ -0,0 +1,23 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// Prefix returns the component name the Logger prepends to its records' messages.
+func (l *Logger) Prefix() string {
+	return l.prefix
+}
+
+// WithPrefix returns a child logger that tags all its records with the component name name. The child shares
+// the writer, the lock and all settings like the loglevel with l, changing a setting on either of them affects both.
+// If l already has a prefix, the child's prefix is l's prefix and name joined by a dot, e.g. "http.router".
+func (l *Logger) WithPrefix(name string) *Logger {
+	if len(name) < 1 {
+		panic("Programming error: (l *Logger) WithPrefix(): Passed empty string as name")
+	}
+	if len(l.prefix) > 0 {
+		name = l.prefix + "." + name
+	}
+	child := *l
+	child.prefix = name
+	return &child
+}
@@ -0,0 +1,30 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithPrefix(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	db := l.WithPrefix("db")
+	pool := db.WithPrefix("pool")
+	if pool.Prefix() != "db.pool" {
+		t.Errorf("Expected prefix %q, got prefix %q", "db.pool", pool.Prefix())
+	}
+	l.Info("root")
+	db.Info("connected")
+	pool.Debug("invisible")
+	db.SetLevel(LevelDebug)
+	pool.Debug("acquired")
+	if l.Level() != LevelDebug {
+		t.Errorf("Level change of a child logger was not shared with its parent")
+	}
+	expect := "[Info] - root\n[Info] - db: connected\n[Debug] - db.pool: acquired\n"
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
@@ -0,0 +1,76 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// ansiClearLine moves the cursor to the start of the line and erases the line.
+const ansiClearLine = "\r\x1b[K"
+
+// ProgressWriter is an io.Writer for terminals that shares the last line with an in-place progress display,
+// like a progress bar. Before a record is written, the progress line is erased, afterwards it is drawn again below
+// the record, so records and progress do not garble each other. If the underlying writer is not a terminal, progress
+// lines are not drawn. A ProgressWriter can be used by multiple goroutines and can be passed to New or (l *Logger) SetOutput.
+type ProgressWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	terminal bool
+	line     string // Progress line, empty if no progress is displayed.
+}
+
+// NewProgressWriter returns a ProgressWriter that writes to w, usually os.Stderr.
+// Passing nil as w will cause a panic.
+func NewProgressWriter(w io.Writer) *ProgressWriter {
+	if w == nil {
+		panic("Programming error: logger.NewProgressWriter: Passed nil as writer")
+	}
+	return &ProgressWriter{w: w, terminal: isTerminal(w)}
+}
+
+// ClearProgress erases the progress line.
+func (p *ProgressWriter) ClearProgress() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.line) < 1 {
+		return nil
+	}
+	p.line = ""
+	_, err := io.WriteString(p.w, ansiClearLine)
+	return err
+}
+
+// SetProgress replaces the progress line by line, e.g. "Downloading 42%". Newlines in line are replaced by spaces.
+// line should be shorter than the width of the terminal, a wrapped line cannot be erased completely.
+// Passing an empty string erases the progress line like ClearProgress.
+func (p *ProgressWriter) SetProgress(line string) error {
+	line = strings.ReplaceAll(line, "\n", " ")
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.terminal || line == p.line {
+		return nil
+	}
+	p.line = line
+	_, err := io.WriteString(p.w, ansiClearLine+line)
+	return err
+}
+
+// Write writes b below the progress line, which is erased first and drawn again afterwards.
+func (p *ProgressWriter) Write(b []byte) (n int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.line) < 1 {
+		return p.w.Write(b)
+	}
+	if _, err := io.WriteString(p.w, ansiClearLine); err != nil {
+		return 0, err
+	}
+	if n, err = p.w.Write(b); err != nil {
+		return n, err
+	}
+	_, err = io.WriteString(p.w, p.line)
+	return n, err
+}
@@ -0,0 +1,28 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProgressWriter(t *testing.T) {
+	b := new(strings.Builder)
+	p := NewProgressWriter(b)
+	p.SetProgress("ignored")
+	if b.String() != "" {
+		t.Errorf("Expected no progress line on a non-terminal. Got %q", b.String())
+	}
+	p.terminal = true
+	l := New(p, LevelInfo, loglevelDelimiter)
+	p.SetProgress("50%")
+	l.Info("record")
+	p.SetProgress("100%")
+	p.ClearProgress()
+	l.Info("done")
+	expected := "\r\x1b[K50%" + "\r\x1b[K[Info] - record\n50%" + "\r\x1b[K100%" + "\r\x1b[K" + "[Info] - done\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,77 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+// The package promhook provides a logger.Hook that counts the records of a Logger per loglevel and exposes the counts
+// as Prometheus metrics, so alerting on error rate spikes is possible without parsing the log output.
+package promhook
+
+import (
+	"strings"
+
+	"github.com/jwdev42/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Hook counts the records of a Logger and implements prometheus.Collector. It exposes the counter
+// <namespace>_log_records_total with the label "level" and the counter <namespace>_log_dropped_records_total
+// holding the number of records the Logger has dropped, see logger.Logger.Dropped.
+type Hook struct {
+	records *prometheus.CounterVec
+	dropped prometheus.CounterFunc
+}
+
+// New constructs a Hook, adds it to l for all loglevels and returns it. The Hook still needs to be registered
+// with a prometheus.Registerer. namespace may be empty.
+func New(l *logger.Logger, namespace string) *Hook {
+	h := &Hook{
+		records: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "log_records_total",
+			Help:      "Number of log records emitted per level.",
+		}, []string{"level"}),
+		dropped: prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "log_dropped_records_total",
+			Help:      "Number of log records dropped by the logger.",
+		}, func() float64 {
+			return float64(l.Dropped())
+		}),
+	}
+	// Initialize all levels so their counters are exported before the first record.
+	for lvl := range logger.Loglevels() {
+		h.records.WithLabelValues(levelLabel(lvl))
+	}
+	l.AddHook(h)
+	return h
+}
+
+// Levels returns all loglevels.
+func (h *Hook) Levels() []logger.Level {
+	levels := make([]logger.Level, 0, len(logger.Loglevels()))
+	for lvl := range logger.Loglevels() {
+		levels = append(levels, lvl)
+	}
+	return levels
+}
+
+// Fire counts rec.
+func (h *Hook) Fire(rec *logger.Record) error {
+	h.records.WithLabelValues(levelLabel(rec.Level)).Inc()
+	return nil
+}
+
+// Describe implements prometheus.Collector.
+func (h *Hook) Describe(ch chan<- *prometheus.Desc) {
+	h.records.Describe(ch)
+	h.dropped.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (h *Hook) Collect(ch chan<- prometheus.Metric) {
+	h.records.Collect(ch)
+	h.dropped.Collect(ch)
+}
+
+// levelLabel returns the value of the label "level" for lvl.
+func levelLabel(lvl logger.Level) string {
+	return strings.ToLower(lvl.String())
+}
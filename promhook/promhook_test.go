@@ -0,0 +1,38 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package promhook
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jwdev42/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestHook(t *testing.T) {
+	l := logger.New(io.Discard, logger.LevelInfo, " - ")
+	h := New(l, "test")
+	l.Error("error")
+	l.Error("error")
+	l.Info("info")
+	l.Debug("filtered")
+	expect := `
+# HELP test_log_records_total Number of log records emitted per level.
+# TYPE test_log_records_total counter
+test_log_records_total{level="alert"} 0
+test_log_records_total{level="audit"} 0
+test_log_records_total{level="critical"} 0
+test_log_records_total{level="debug"} 0
+test_log_records_total{level="error"} 2
+test_log_records_total{level="info"} 1
+test_log_records_total{level="notice"} 0
+test_log_records_total{level="panic"} 0
+test_log_records_total{level="trace"} 0
+test_log_records_total{level="warning"} 0
+`
+	if err := testutil.CollectAndCompare(h, strings.NewReader(expect), "test_log_records_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,203 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Record is the canonical representation of a log record. Every record that is sent to a Logger
+// is turned into a Record before it is rendered and written.
+type Record struct {
+	Level   Level           // Loglevel of the record.
+	Time    time.Time       // Time the record was created.
+	Prefix  string          // Component name of the Logger that created the record.
+	Message string          // Log message, without a trailing newline.
+	Fields  []Field         // Key/value pairs attached to the record.
+	Caller  runtime.Frame   // Location in the code that created the record, the zero value means unknown.
+	Stack   string          // Stack trace of the goroutine that created the record, empty if none was captured.
+	depth   int             // Number of groups the record belongs to, see (l *Logger) Group.
+	ctx     context.Context // Context passed to PrintCtx, its fields are extracted by the Logger's context extractor.
+	trace   bool            // A stack trace is captured regardless of the Logger's stacktrace level, see RecoverAndLog.
+}
+
+// HasCaller returns true if the record holds caller information.
+func (r *Record) HasCaller() bool {
+	return r.Caller.PC != 0 || len(r.Caller.File) > 0
+}
+
+// Output writes rec if its level is equally severe or more severe than that set for the Logger.
+// If rec.Time is the zero time, it is set to the current time of the Logger's clock. If rec.Prefix is empty, it is set to the Logger's prefix.
+// The fields of a Logger created by WithFields are prepended to rec.Fields.
+// A trailing newline in rec.Message is removed. A message that exceeds the maximum length is treated according
+// to the Logger's length policy, see SetMaxLength. An asynchronous Logger queues rec and returns 0 bytes written.
+// rec is passed to the Logger's hooks before it is written, if a hook fails, rec is written anyway and the hook's
+// error is returned unless writing failed as well.
+func (l *Logger) Output(rec Record) (n int, err error) {
+	n, _, err = l.output(rec)
+	return n, err
+}
+
+// OutputOK writes rec like Output and additionally reports whether rec was written. ok is false if rec was
+// suppressed by the Logger's loglevel, sampler, deduplication or length policy or because the Logger is
+// discarding or closed. Unlike n, ok is also true for a record that was queued by an asynchronous Logger.
+func (l *Logger) OutputOK(rec Record) (n int, ok bool, err error) {
+	return l.output(rec)
+}
+
+// output implements Output and OutputOK.
+func (l *Logger) output(rec Record) (n int, written bool, err error) {
+	if l.discard {
+		return 0, false, nil
+	}
+	if l.closed.Load() {
+		l.dropped.Add(1)
+		return 0, false, ErrClosed
+	}
+	if l.shards != nil {
+		return l.shard(rec)
+	}
+	if !l.trigger(rec.Level) && !l.recording.Load() {
+		return 0, false, nil
+	}
+	if !l.lockOrQueue(&rec) {
+		return 0, true, nil
+	}
+	l.prepare(&rec)
+	if l.sampler != nil && rec.Level != LevelAudit && !l.sampler.Sample(&rec) {
+		l.unlock()
+		return 0, false, nil
+	}
+	if l.needsStack(&rec) {
+		rec.Stack = l.stacktrace()
+	}
+	var buf [1]*Record
+	recs, summary, hookErr := l.process(&rec, buf[:0])
+	if recs == nil {
+		l.unlock()
+		return 0, false, nil
+	}
+	if l.async != nil {
+		l.unlock()
+		if summary != nil {
+			l.enqueue(asyncItem{rec: summary})
+		}
+		for i, r := range recs {
+			if err := l.enqueue(asyncItem{rec: r}); err != nil {
+				return 0, i > 0, err
+			}
+		}
+		return 0, true, hookErr
+	}
+	defer l.unlock()
+	if summary != nil {
+		l.write(summary)
+	}
+	for _, r := range recs {
+		m, err := l.write(r)
+		n += m
+		if err != nil {
+			return n, true, err
+		}
+	}
+	return n, true, hookErr
+}
+
+// prepare sets the time, the prefix, the Logger's fields, the fields of the context and the caller of rec
+// and evaluates its lazy field values. The caller must hold the Logger's lock or its read lock.
+func (l *Logger) prepare(rec *Record) {
+	if rec.Time.IsZero() {
+		rec.Time = l.now()
+	}
+	l.applyScope(rec)
+	l.extract(rec)
+	resolveFields(rec)
+	if l.reportCaller && !rec.HasCaller() {
+		rec.Caller = l.caller()
+	}
+}
+
+// applyScope sets the prefix of rec to the Logger's prefix unless rec has one, prepends the Logger's fields
+// to the fields of rec and records the groups it belongs to. It does not need the Logger's lock.
+func (l *Logger) applyScope(rec *Record) {
+	rec.depth = int(l.groups.Load())
+	if len(rec.Prefix) < 1 {
+		rec.Prefix = l.prefix
+	}
+	if len(l.fields) > 0 {
+		rec.Fields = append(l.fields[:len(l.fields):len(l.fields)], rec.Fields...)
+	}
+}
+
+// process applies the filters, the secret policy, the transformers, the redactors, the deduplication and
+// the length policy to rec and fires the hooks. It appends the records to write to recs and returns them, nil if
+// rec is suppressed, and the summary of the deduplication that has to be written first, if any. Passing a buffer
+// for recs keeps the common case of a single record free of allocations. The caller must hold the Logger's lock.
+func (l *Logger) process(rec *Record, recs []*Record) ([]*Record, *Record, error) {
+	if len(l.filters) > 0 && rec.Level != LevelAudit && !l.filter(rec) {
+		return nil, nil, nil
+	}
+	if l.recorder != nil && rec.Level > Level(l.level.Load()) {
+		l.refine(rec)
+		l.recorder.add(rec)
+		return nil, nil, nil
+	}
+	if l.diskGuard != nil {
+		var discard bool
+		if recs, discard = l.guardDisk(rec, recs); discard {
+			if len(recs) < 1 {
+				return nil, nil, nil
+			}
+			return recs, nil, l.fireHooks(recs[0])
+		}
+	}
+	l.refine(rec)
+	var summary *Record
+	if l.dedup != nil && rec.Level != LevelAudit {
+		var suppress bool
+		if summary, suppress = l.deduplicate(rec); suppress {
+			return nil, nil, nil
+		}
+	}
+	if l.recorder != nil && rec.Level <= l.recorder.threshold {
+		recs = l.replay(recs)
+	}
+	if recs = l.appendLimited(recs, rec); len(recs) < 1 {
+		return nil, nil, nil
+	}
+	var hookErr error
+	for _, r := range recs {
+		if err := l.fireHooks(r); err != nil && hookErr == nil {
+			hookErr = err
+		}
+	}
+	return recs, summary, hookErr
+}
+
+// refine trims the trailing newline of the message of rec and applies the secret policy, the transformers
+// and the redactors to rec. The caller must hold the Logger's lock.
+func (l *Logger) refine(rec *Record) {
+	rec.Message = strings.TrimSuffix(rec.Message, "\n")
+	if l.deterministic.Load() && len(rec.Fields) > 1 {
+		sortFields(rec)
+	}
+	l.applySecretPolicy(rec)
+	if len(l.transformers) > 0 {
+		l.transform(rec)
+	}
+	if len(l.redactors) > 0 {
+		l.redact(rec)
+	}
+}
+
+// appendLimited appends rec to recs, split or truncated according to the Logger's length policy.
+// The caller must hold the Logger's lock.
+func (l *Logger) appendLimited(recs []*Record, rec *Record) []*Record {
+	if l.maxLength > 0 && len(rec.Message) > l.maxLength {
+		return append(recs, l.limitLength(rec)...)
+	}
+	return append(recs, rec)
+}
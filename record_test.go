@@ -0,0 +1,56 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutput(t *testing.T) {
+	const layout = "2006-01-02 15:04:05"
+	ts := time.Date(2023, time.March, 14, 15, 9, 26, 0, time.UTC)
+	b := new(strings.Builder)
+	l := New(b, LevelNotice, loglevelDelimiter)
+	l.SetTimeFormat(layout)
+	l.Output(Record{Level: LevelInfo, Time: ts, Message: "filtered"})
+	if b.String() != "" {
+		t.Errorf("Record %q should not have been printed", b.String())
+	}
+	l.Output(Record{
+		Level:   LevelNotice,
+		Time:    ts,
+		Message: "disk almost full\n",
+		Fields:  []Field{{Key: "free", Value: "3%"}},
+	})
+	expect := fmt.Sprintf("[%s]%s%s%sdisk almost full%sfree=3%%\n",
+		LevelNotice.String(),
+		loglevelDelimiter,
+		ts.Format(layout),
+		loglevelDelimiter,
+		loglevelDelimiter)
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
+
+func TestOutputOK(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	if n, ok, err := l.PrintlnOK(LevelInfo, ""); n != len("[Info] - \n") || !ok || err != nil {
+		t.Errorf("Expected a written record. Got %d, %t, %v", n, ok, err)
+	}
+	if n, ok, err := l.PrintfOK(LevelDebug, "%d", 1); n != 0 || ok || err != nil {
+		t.Errorf("Expected a filtered record. Got %d, %t, %v", n, ok, err)
+	}
+	l.SetMaxLength(3, LengthDrop)
+	if _, ok, _ := l.OutputOK(Record{Level: LevelInfo, Message: "too long"}); ok {
+		t.Error("Expected a dropped record")
+	}
+	l.Close()
+	if _, ok, err := l.PrintlnOK(LevelInfo, "closed"); ok || err != ErrClosed {
+		t.Errorf("Expected %v. Got %t, %v", ErrClosed, ok, err)
+	}
+}
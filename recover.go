@@ -0,0 +1,72 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Registers the recovery functions as functions that are skipped when determining the caller of a record.
+func init() {
+	skipFunctions(RecoverAndLog, RecoverAndRepanic)
+}
+
+// RecoverAndLog recovers from a panic and sends the panic value with a stack trace of the panicking goroutine
+// with loglevel LevelPanic to l. It must be called directly by a defer statement:
+//
+//	defer logger.RecoverAndLog(l)
+//
+// The panic is stopped, the surrounding function returns normally.
+func RecoverAndLog(l *Logger) {
+	if v := recover(); v != nil {
+		passExitRequest(v)
+		l.logPanic(v)
+	}
+}
+
+// RecoverAndRepanic works like RecoverAndLog, but continues panicking with the same value after the panic has been logged.
+func RecoverAndRepanic(l *Logger) {
+	if v := recover(); v != nil {
+		passExitRequest(v)
+		l.logPanic(v)
+		panic(v)
+	}
+}
+
+// RecoverMiddleware returns an http.Handler that calls next and recovers from panics in next. A panic is logged
+// like RecoverAndLog does, with the request's method and path attached, then the client receives the status
+// 500 Internal Server Error. The panic http.ErrAbortHandler is not logged and continues panicking, so net/http
+// can abort the response.
+func RecoverMiddleware(l *Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer l.recoverHTTP(w, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// passExitRequest continues panicking if the panic value v is an exit request of the deferred exit mode, see SetDeferredExit.
+func passExitRequest(v any) {
+	if _, ok := v.(*exitRequest); ok {
+		panic(v)
+	}
+}
+
+// logPanic sends the panic value v with the key/value pairs kv and a stack trace to the Logger.
+func (l *Logger) logPanic(v any, kv ...any) {
+	l.Output(Record{Level: LevelPanic, Message: fmt.Sprintf("panic: %v", v), Fields: fieldsFromKV(kv), trace: true})
+}
+
+// recoverHTTP recovers from a panic of the handler serving r, see RecoverMiddleware. It must be called directly by a defer statement.
+func (l *Logger) recoverHTTP(w http.ResponseWriter, r *http.Request) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	if v == http.ErrAbortHandler {
+		panic(v)
+	}
+	passExitRequest(v)
+	l.logPanic(v, "method", r.Method, "path", r.URL.Path)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
@@ -0,0 +1,62 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func panickingFunction() {
+	panic("boom")
+}
+
+func TestRecoverAndLog(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	func() {
+		defer RecoverAndLog(l)
+		panickingFunction()
+	}()
+	lines := strings.Split(b.String(), "\n")
+	if lines[0] != "[Panic] - panic: boom" {
+		t.Errorf("Expected %q. Got %q", "[Panic] - panic: boom", lines[0])
+	}
+	if len(lines) < 2 || lines[1] != "github.com/jwdev42/logger.panickingFunction" {
+		t.Errorf("Expected the stack trace to start at the panicking function. Got %q", b.String())
+	}
+}
+
+func TestRecoverAndRepanic(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	defer func() {
+		if v := recover(); v != "boom" {
+			t.Errorf("Expected the panic to continue with %q. Got %v", "boom", v)
+		}
+		if !strings.HasPrefix(b.String(), "[Panic] - panic: boom\n") {
+			t.Errorf("Expected the panic to be logged. Got %q", b.String())
+		}
+	}()
+	defer RecoverAndRepanic(l)
+	panickingFunction()
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	h := RecoverMiddleware(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panickingFunction()
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/crash", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d. Got %d", http.StatusInternalServerError, rec.Code)
+	}
+	const expected = "[Panic] - panic: boom - method=GET path=/crash\ngithub.com/jwdev42/logger.panickingFunction\n"
+	if !strings.HasPrefix(b.String(), expected) {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
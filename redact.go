@@ -0,0 +1,123 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import "regexp"
+
+// redactedText replaces the sensitive data removed by the predefined redactors.
+const redactedText = "[REDACTED]"
+
+// Redactor masks sensitive data in s and returns the result. It must return s unchanged if s holds no sensitive data.
+type Redactor func(s string) string
+
+// Predefined redactors.
+var (
+	// RedactCreditCards masks all but the last 4 digits of credit card numbers that pass the Luhn check,
+	// e.g. "4111 1111 1111 1111" becomes "**** **** **** 1111".
+	RedactCreditCards Redactor = redactCreditCards
+	// RedactBearerTokens replaces the tokens of HTTP bearer authorizations like "Bearer eyJhbGciOi..." with "[REDACTED]".
+	RedactBearerTokens Redactor = RegexpRedactor(regexp.MustCompile(`(?i)(\bbearer\s+)[a-z0-9._~+/-]+=*`), "${1}"+redactedText)
+)
+
+// creditCardPattern matches candidates for credit card numbers, sequences of 13 to 19 digits that may be grouped by spaces or dashes.
+var creditCardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+// RegexpRedactor returns a Redactor that replaces the matches of re with replacement, which may refer to
+// submatches like regexp.Regexp.ReplaceAllString does.
+func RegexpRedactor(re *regexp.Regexp, replacement string) Redactor {
+	if re == nil {
+		panic("Programming error: logger.RegexpRedactor: Passed nil as regular expression")
+	}
+	return func(s string) string {
+		return re.ReplaceAllString(s, replacement)
+	}
+}
+
+// AddRedactor adds a Redactor that is applied to the message and the field values of every record before the record
+// is passed to hooks, sinks and outputs. Field values that are changed by a Redactor are replaced by the redacted
+// text. Redactors are applied in the order they were added.
+func (l *Logger) AddRedactor(r Redactor) {
+	if r == nil {
+		panic("Programming error: (l *Logger) AddRedactor(): Passed nil as redactor")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.redactors = append(l.redactors, r)
+}
+
+// AddRedactPattern adds a Redactor that replaces all matches of the regular expression pattern with "[REDACTED]".
+// Passing an invalid regular expression will cause a panic.
+func (l *Logger) AddRedactPattern(pattern string) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		panic("Programming error: (l *Logger) AddRedactPattern(): Passed invalid regular expression: " + err.Error())
+	}
+	l.AddRedactor(RegexpRedactor(re, redactedText))
+}
+
+// redact applies the Logger's redactors to the message and the field values of rec. The fields are copied
+// before they are modified. The caller must hold the Logger's lock.
+func (l *Logger) redact(rec *Record) {
+	rec.Message = l.redactString(rec.Message)
+	copied := false
+	for i, f := range rec.Fields {
+		value := fieldValueString(f.Value)
+		redacted := l.redactString(value)
+		if redacted == value {
+			continue
+		}
+		if !copied {
+			rec.Fields = append([]Field(nil), rec.Fields...)
+			copied = true
+		}
+		rec.Fields[i].Value = redacted
+	}
+}
+
+// redactString applies the Logger's redactors to s. The caller must hold the Logger's lock.
+func (l *Logger) redactString(s string) string {
+	for _, r := range l.redactors {
+		s = r(s)
+	}
+	return s
+}
+
+// redactCreditCards implements RedactCreditCards.
+func redactCreditCards(s string) string {
+	return creditCardPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if !luhnValid(match) {
+			return match
+		}
+		b := []byte(match)
+		digits := 0
+		for i := len(b) - 1; i >= 0; i-- {
+			if b[i] < '0' || b[i] > '9' {
+				continue
+			}
+			if digits++; digits > 4 {
+				b[i] = '*'
+			}
+		}
+		return string(b)
+	})
+}
+
+// luhnValid returns true if the digits in s pass the Luhn checksum. Characters other than digits are ignored.
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+		d := int(s[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
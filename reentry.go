@@ -0,0 +1,195 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// maxReentrancyDepth is the number of times a record sent from within a Logger, e.g. by a Hook, may lead to another
+// record sent from within the Logger. Deeper records are discarded to stop endless recursion.
+const maxReentrancyDepth = 8
+
+// ErrReentrancy is passed to the error handler of a Logger, see (l *Logger) SetErrorHandler, when a record is
+// discarded because records sent from within the Logger kept sending records, e.g. a Hook that logs on every record.
+// It is also returned by methods like Flush that cannot be carried out from within the Logger while it writes records.
+var ErrReentrancy = errors.New("Reentrant call from within the Logger discarded")
+
+// writingFunctions holds the names of the methods that call code outside of the Logger, like hooks and writers,
+// while holding the Logger's lock.
+var writingFunctions = make(map[string]bool)
+
+// Registers the methods that call hooks, sinks, writers and error handlers as writing functions.
+func init() {
+	for _, fn := range []any{
+		(*Logger).process, (*Logger).write, (*Logger).writePending, (*Logger).flushWriters,
+		(*Logger).handleError, (*Logger).mergeShards,
+	} {
+		writingFunctions[runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()] = true
+	}
+}
+
+// reentrancy holds the records a Logger received from within its own hooks, writers or other user code it called
+// while holding its lock. Waiting for the lock would deadlock, so these records are written before the lock is released.
+type reentrancy struct {
+	mu      sync.Mutex
+	writing atomic.Bool // The Logger's lock is held by a goroutine that writes records. Only cleared while holding mu.
+	depth   int         // Reentrancy depth of the record that is written.
+	pending []pendingRecord
+}
+
+// pendingRecord is a record queued by lockOrQueue.
+type pendingRecord struct {
+	rec   Record
+	pcs   []uintptr // Stack of the goroutine that sent the record.
+	depth int
+}
+
+// lock acquires the Logger's lock to write records. It must be released by unlock.
+func (l *Logger) lock() {
+	l.mu.Lock()
+	l.reentry.writing.Store(true)
+}
+
+// lockOrQueue acquires the Logger's lock like lock and returns true. If the lock is held by a goroutine writing records
+// and the calling goroutine runs code called by a Logger, like a Hook or a Writer, rec is queued instead and false
+// is returned. The goroutine holding the lock writes rec before releasing the lock. If both goroutines are the same,
+// waiting for the lock would deadlock.
+func (l *Logger) lockOrQueue(rec *Record) bool {
+	if l.mu.TryLock() {
+		l.reentry.writing.Store(true)
+		return true
+	}
+	if calledFromLogger() {
+		r := l.reentry
+		r.mu.Lock()
+		if l.mu.TryLock() {
+			r.writing.Store(true)
+			r.mu.Unlock()
+			return true
+		}
+		if r.writing.Load() {
+			l.applyScope(rec)
+			pcs := make([]uintptr, maxStackDepth)
+			r.pending = append(r.pending, pendingRecord{rec: *rec, pcs: pcs[:runtime.Callers(2, pcs)], depth: r.depth + 1})
+			r.mu.Unlock()
+			return false
+		}
+		r.mu.Unlock()
+	}
+	l.lock()
+	return true
+}
+
+// rlock acquires the Logger's read lock to read its settings and returns true. If the lock is held by a goroutine
+// writing records and the calling goroutine runs code called by a Logger, like a Hook or a Writer, waiting might deadlock.
+// rlock returns false without the read lock then, but holding the lock of the reentrancy state, which keeps the writing
+// goroutine from releasing the Logger's lock. Settings are only changed without writing records, so they can be read
+// until runlock is called with the result of rlock.
+func (l *Logger) rlock() bool {
+	if l.mu.TryRLock() {
+		return true
+	}
+	if calledFromLogger() {
+		r := l.reentry
+		r.mu.Lock()
+		if r.writing.Load() {
+			return false
+		}
+		r.mu.Unlock()
+	}
+	l.mu.RLock()
+	return true
+}
+
+// runlock releases what rlock acquired, locked is the result of rlock.
+func (l *Logger) runlock(locked bool) {
+	if locked {
+		l.mu.RUnlock()
+	} else {
+		l.reentry.mu.Unlock()
+	}
+}
+
+// reentrant returns true if the calling goroutine runs code called by a Logger, like a Hook or a Writer, while the
+// Logger's lock is held by a goroutine writing records. Waiting for the Logger's lock or its writes might deadlock then.
+func (l *Logger) reentrant() bool {
+	return l.reentry.writing.Load() && calledFromLogger()
+}
+
+// unlock writes the records queued by lockOrQueue, then releases the Logger's lock acquired by lock.
+func (l *Logger) unlock() {
+	r := l.reentry
+	for {
+		r.mu.Lock()
+		if len(r.pending) < 1 {
+			r.writing.Store(false)
+			r.depth = 0
+			l.mu.Unlock()
+			r.mu.Unlock()
+			return
+		}
+		p := r.pending[0]
+		r.pending = r.pending[1:]
+		r.depth = p.depth
+		r.mu.Unlock()
+		l.writePending(&p)
+	}
+}
+
+// writePending writes a record queued by lockOrQueue. The caller must hold the Logger's lock.
+func (l *Logger) writePending(p *pendingRecord) {
+	if p.depth > maxReentrancyDepth {
+		l.dropped.Add(1)
+		if p.depth == maxReentrancyDepth+1 {
+			l.handleError(ErrReentrancy)
+		}
+		return
+	}
+	rec := &p.rec
+	if rec.Time.IsZero() {
+		rec.Time = l.now()
+	}
+	l.extract(rec)
+	resolveFields(rec)
+	if l.reportCaller && !rec.HasCaller() {
+		rec.Caller = callerOf(p.pcs, l.callerSkip)
+	}
+	if l.sampler != nil && rec.Level != LevelAudit && !l.sampler.Sample(rec) {
+		return
+	}
+	if l.needsStack(rec) {
+		rec.Stack = stacktraceOf(p.pcs, l.callerSkip)
+	}
+	var buf [1]*Record
+	recs, summary, hookErr := l.process(rec, buf[:0])
+	if summary != nil {
+		l.write(summary)
+	}
+	for _, r := range recs {
+		l.write(r)
+	}
+	if hookErr != nil {
+		l.handleError(hookErr)
+	}
+}
+
+// calledFromLogger returns true if the calling goroutine runs code that was called by a Logger while writing
+// a record, i.e. a writing function is on the stack.
+func calledFromLogger() bool {
+	var pcs [maxStackDepth]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if writingFunctions[frame.Function] {
+			return true
+		}
+		if !more {
+			return false
+		}
+	}
+}
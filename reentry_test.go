@@ -0,0 +1,218 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// loggingHook logs msg to l whenever it is fired.
+type loggingHook struct {
+	l      *Logger
+	levels []Level
+	msg    string
+}
+
+func (h *loggingHook) Levels() []Level {
+	return h.levels
+}
+
+func (h *loggingHook) Fire(rec *Record) error {
+	h.l.Info(h.msg)
+	return nil
+}
+
+// ctxHook logs msg with ctx to l whenever it is fired.
+type ctxHook struct {
+	l   *Logger
+	ctx context.Context
+	msg string
+}
+
+func (h *ctxHook) Levels() []Level {
+	return []Level{LevelWarning}
+}
+
+func (h *ctxHook) Fire(rec *Record) error {
+	h.l.InfoCtx(h.ctx, h.msg)
+	h.l.Assert(false, "from hook")
+	return nil
+}
+
+// funcHook calls f whenever it is fired.
+type funcHook func()
+
+func (h funcHook) Levels() []Level {
+	return []Level{LevelWarning}
+}
+
+func (h funcHook) Fire(rec *Record) error {
+	h()
+	return nil
+}
+
+// loggingWriter writes to b and logs to l if a written record contains trigger.
+type loggingWriter struct {
+	b       *strings.Builder
+	l       *Logger
+	trigger string
+}
+
+func (w *loggingWriter) Write(p []byte) (int, error) {
+	w.b.Write(p)
+	if strings.Contains(string(p), w.trigger) {
+		w.l.Info("written by writer")
+	}
+	return len(p), nil
+}
+
+// withoutDeadlock fails the test if f does not return within a second.
+func withoutDeadlock(t *testing.T, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		f()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Logging from within the Logger deadlocked")
+	}
+}
+
+func TestReentrantHook(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.AddHook(&loggingHook{l: l.WithPrefix("hook"), levels: []Level{LevelWarning}, msg: "fired"})
+	withoutDeadlock(t, func() {
+		l.Warning("first")
+		l.Info("second")
+	})
+	expected := "[Warning] - first\n[Info] - hook: fired\n[Info] - second\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestReentrantWriter(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		w := &loggingWriter{b: new(strings.Builder), trigger: "trigger"}
+		var l *Logger
+		if async {
+			l = NewAsync(w, LevelInfo, loglevelDelimiter, 4)
+		} else {
+			l = New(w, LevelInfo, loglevelDelimiter)
+		}
+		w.l = l
+		withoutDeadlock(t, func() {
+			l.Info("trigger")
+			l.Flush()
+			l.Close()
+		})
+		expected := "[Info] - trigger\n[Info] - written by writer\n"
+		if w.b.String() != expected {
+			t.Errorf("Async %t: Expected %q. Got %q", async, expected, w.b.String())
+		}
+	}
+}
+
+func TestReentrancyDepth(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	var errs []error
+	l.SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+	l.AddHook(&loggingHook{l: l, levels: []Level{LevelInfo}, msg: "again"})
+	withoutDeadlock(t, func() {
+		l.Info("start")
+	})
+	if lines := strings.Count(b.String(), "\n"); lines != maxReentrancyDepth+1 {
+		t.Errorf("Expected %d records. Got %d", maxReentrancyDepth+1, lines)
+	}
+	if len(errs) != 1 || errs[0] != ErrReentrancy || l.Dropped() != 1 {
+		t.Errorf("Expected %v once and 1 dropped record. Got %v and %d", ErrReentrancy, errs, l.Dropped())
+	}
+}
+
+func TestReentrantContext(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetContextExtractor(func(ctx context.Context) []Field {
+		return []Field{{Key: "trace", Value: "abc"}}
+	})
+	l.AddHook(&ctxHook{l: l, ctx: ContextWithFields(context.Background(), "user", "bob"), msg: "fired"})
+	withoutDeadlock(t, func() {
+		l.Warning("first")
+	})
+	expected := "[Warning] - first\n[Info] - fired - user=bob trace=abc\n[Panic] - Assertion failed: from hook\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}
+
+func TestReentrantMethods(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(l *Logger) error
+	}{
+		{"Format", func(l *Logger) error {
+			if f := l.Format(); f != FormatText {
+				return fmt.Errorf("Unexpected format %s", f)
+			}
+			return nil
+		}},
+		{"TimeFormat", func(l *Logger) error {
+			l.TimeFormat()
+			return nil
+		}},
+		{"SetLevel", func(l *Logger) error {
+			l.SetLevel(LevelDebug)
+			if l.Level() != LevelDebug {
+				return fmt.Errorf("Unexpected level %s", l.Level())
+			}
+			return nil
+		}},
+		{"Stats", func(l *Logger) error {
+			if n := l.Stats().Records[LevelWarning]; n != 0 {
+				return fmt.Errorf("Expected the record being written not to be counted yet. Got %d", n)
+			}
+			return nil
+		}},
+		{"Flush", func(l *Logger) error {
+			if err := l.Flush(); err != ErrReentrancy {
+				return fmt.Errorf("Expected %v. Got %v", ErrReentrancy, err)
+			}
+			return nil
+		}},
+		{"Options", func(l *Logger) error {
+			if opts := l.Options(); opts.Level != LevelInfo {
+				return fmt.Errorf("Unexpected level %s", opts.Level)
+			}
+			return nil
+		}},
+		{"Apply", func(l *Logger) error {
+			if err := l.Apply(l.Options()); err != ErrReentrancy {
+				return fmt.Errorf("Expected %v. Got %v", ErrReentrancy, err)
+			}
+			return nil
+		}},
+	}
+	for _, test := range tests {
+		l := New(io.Discard, LevelInfo, loglevelDelimiter)
+		var err error
+		l.AddHook(funcHook(func() { err = test.call(l) }))
+		withoutDeadlock(t, func() {
+			l.Warning("call")
+		})
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+	}
+}
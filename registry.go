@@ -0,0 +1,112 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"sort"
+	"sync"
+)
+
+// registry holds the named loggers and the LevelSpec applied to them.
+var registry struct {
+	sync.Mutex
+	loggers map[string]*Logger
+	spec    LevelSpec
+}
+
+// Named returns the logger registered under name. If there is none, a child of the default logger, see Default and
+// (l *Logger) WithPrefix, is prefixed with name and registered. Named loggers write through the default logger, but
+// have their own loglevel, which is taken from the LevelSpec set by SetLevelSpec. Names of subsystems can be nested
+// by dots, e.g. "db.sql". Named can be used by multiple goroutines. Passing an empty string as name will cause a panic.
+func Named(name string) *Logger {
+	if len(name) < 1 {
+		panic("Programming error: logger.Named: Passed empty string as name")
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if l, ok := registry.loggers[name]; ok {
+		return l
+	}
+	l := Default().WithPrefix(name)
+	level := new(loglevel)
+	level.Store(l.level.Load())
+	if lvl := registry.spec.Level(name); lvl != LevelInvalid {
+		level.Store(int32(lvl))
+	}
+	l.level = level
+	registerLogger(name, l)
+	return l
+}
+
+// Register registers l as the named logger name, replacing a logger registered under the same name, see Named.
+// The loglevel of l is set according to the LevelSpec set by SetLevelSpec. Passing an empty string as name
+// or nil as Logger will cause a panic.
+func Register(name string, l *Logger) {
+	if len(name) < 1 {
+		panic("Programming error: logger.Register: Passed empty string as name")
+	}
+	if l == nil {
+		panic("Programming error: logger.Register: Passed nil as Logger")
+	}
+	registry.Lock()
+	registerLogger(name, l)
+	lvl := registry.spec.Level(name)
+	registry.Unlock()
+	if lvl != LevelInvalid {
+		l.SetLevel(lvl)
+	}
+}
+
+// RegisteredNames returns the names of all named loggers in lexical order.
+func RegisteredNames() []string {
+	registry.Lock()
+	defer registry.Unlock()
+	names := make([]string, 0, len(registry.loggers))
+	for name := range registry.loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetLevelSpec sets the loglevels of the default logger and of all named loggers according to spec, named loggers
+// that are created or registered later get their loglevel from spec, too. SetLevelSpec can be used by multiple goroutines.
+// A spec with an invalid loglevel will cause a panic.
+func SetLevelSpec(spec LevelSpec) {
+	if spec.Default != LevelInvalid {
+		assertLoglevel(spec.Default)
+	}
+	copied := LevelSpec{Default: spec.Default, Levels: make(map[string]Level, len(spec.Levels))}
+	for name, lvl := range spec.Levels {
+		assertLoglevel(lvl)
+		copied.Levels[name] = lvl
+	}
+	// The loglevels are set after releasing the registry's lock, level change listeners may use the registry.
+	type change struct {
+		l     *Logger
+		level Level
+	}
+	var changes []change
+	registry.Lock()
+	registry.spec = copied
+	if copied.Default != LevelInvalid {
+		changes = append(changes, change{Default(), copied.Default})
+	}
+	for name, l := range registry.loggers {
+		if lvl := copied.Level(name); lvl != LevelInvalid {
+			changes = append(changes, change{l, lvl})
+		}
+	}
+	registry.Unlock()
+	for _, c := range changes {
+		c.l.SetLevel(c.level)
+	}
+}
+
+// registerLogger registers l under name. The caller must hold the registry's lock.
+func registerLogger(name string, l *Logger) {
+	if registry.loggers == nil {
+		registry.loggers = make(map[string]*Logger)
+	}
+	registry.loggers[name] = l
+}
@@ -0,0 +1,67 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNamed(t *testing.T) {
+	b := new(strings.Builder)
+	SetDefault(New(b, LevelInfo, loglevelDelimiter))
+	defer SetDefault(New(new(strings.Builder), LevelInfo, loglevelDelimiter))
+	spec, err := ParseLevelSpec("registry.db=debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetLevelSpec(spec)
+	defer SetLevelSpec(LevelSpec{})
+	db := Named("registry.db")
+	if Named("registry.db") != db {
+		t.Error("Named returned a different logger for the same name")
+	}
+	http := Named("registry.http")
+	db.Debug("query")
+	http.Debug("request")
+	if b.String() != "[Debug] - registry.db: query\n" {
+		t.Errorf("Expected %q. Got %q", "[Debug] - registry.db: query\n", b.String())
+	}
+	custom := New(b, LevelError, loglevelDelimiter)
+	Register("registry.db.sql", custom)
+	if custom.Level() != LevelDebug {
+		t.Errorf("Expected level %s, got level %s", LevelDebug, custom.Level())
+	}
+	SetLevelSpec(LevelSpec{Levels: map[string]Level{"registry.http": LevelTrace}})
+	if http.Level() != LevelTrace || db.Level() != LevelDebug || Default().Level() != LevelInfo {
+		t.Errorf("Unexpected levels %s, %s and %s", http.Level(), db.Level(), Default().Level())
+	}
+	if names := RegisteredNames(); !slices.Contains(names, "registry.db.sql") || !slices.IsSorted(names) {
+		t.Errorf("Unexpected names %v", names)
+	}
+}
+
+func TestNamedSharesWriter(t *testing.T) {
+	b := new(strings.Builder)
+	SetDefault(New(b, LevelInfo, loglevelDelimiter))
+	defer SetDefault(New(new(strings.Builder), LevelInfo, loglevelDelimiter))
+	defer SetLevelSpec(LevelSpec{})
+	a, c := Named("registry.shared.a"), Named("registry.shared.b")
+	if a.mu != c.mu || a.mu != Default().mu {
+		t.Error("Named loggers do not share the lock of the default logger")
+	}
+	var names []string
+	a.OnLevelChange(func(old, new Level) {
+		names = RegisteredNames()
+	})
+	withoutDeadlock(t, func() {
+		SetLevelSpec(LevelSpec{Levels: map[string]Level{"registry.shared.a": LevelDebug}})
+	})
+	if !slices.Contains(names, "registry.shared.a") {
+		t.Errorf("Level change listener got unexpected names %v", names)
+	}
+	if a.Level() != LevelDebug || c.Level() != LevelInfo || Default().Level() != LevelInfo {
+		t.Errorf("Unexpected levels %s, %s and %s", a.Level(), c.Level(), Default().Level())
+	}
+}
@@ -0,0 +1,149 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+// LevelSource returns a loglevel from an external source like an environment variable or a file.
+type LevelSource func() (Level, error)
+
+// EnvLevelSource returns a LevelSource that parses the loglevel from the environment variable name.
+func EnvLevelSource(name string) LevelSource {
+	return func() (Level, error) {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return LevelInvalid, fmt.Errorf("Environment variable %q is not set", name)
+		}
+		return ParseLevel(strings.TrimSpace(value))
+	}
+}
+
+// FileLevelSource returns a LevelSource that parses the loglevel from the content of the file at path.
+func FileLevelSource(path string) LevelSource {
+	return func() (Level, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return LevelInvalid, err
+		}
+		return ParseLevel(strings.TrimSpace(string(b)))
+	}
+}
+
+// OnLevelChange registers fn to be called every time the Logger's loglevel is changed to a different one.
+// fn is called after the change, it may log to the Logger.
+func (l *Logger) OnLevelChange(fn func(old, new Level)) {
+	if fn == nil {
+		panic("Programming error: (l *Logger) OnLevelChange(): Passed nil as function")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level.listeners = append(l.level.listeners, fn)
+}
+
+// ReloadLevel sets the Logger's loglevel to the one returned by source.
+// If source fails, the loglevel is not changed and the error is returned.
+func (l *Logger) ReloadLevel(source LevelSource) error {
+	level, err := source()
+	if err != nil {
+		return err
+	}
+	l.SetLevel(level)
+	return nil
+}
+
+// ReloadLevelOnSignal reloads the Logger's loglevel from source every time the process receives one of sigs,
+// if sigs is empty, SIGHUP is used. Errors are passed to the Logger's error handler. Calling the returned
+// function stops the reloading. Passing no signal on a system without SIGHUP will cause a panic.
+func (l *Logger) ReloadLevelOnSignal(source LevelSource, sigs ...os.Signal) (stop func()) {
+	if len(sigs) < 1 {
+		sigs = defaultSignals
+	}
+	if len(sigs) < 1 {
+		panic("Programming error: (l *Logger) ReloadLevelOnSignal(): Passed no signal on a system without SIGHUP")
+	}
+	c := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(c, sigs...)
+	go func() {
+		for {
+			select {
+			case <-c:
+				if err := l.ReloadLevel(source); err != nil {
+					l.lock()
+					l.handleError(err)
+					l.unlock()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(c)
+		close(done)
+	}
+}
+
+// ShiftLevelOnSignal makes the Logger more verbose by one loglevel every time the process receives up
+// and less verbose by one loglevel every time it receives down. The loglevel stays between LevelPanic and
+// LevelTrace. Every change is logged at LevelAudit, so the announcement passes whatever loglevel the Logger
+// was shifted to. Calling the returned function stops the shifting.
+func (l *Logger) ShiftLevelOnSignal(up, down os.Signal) (stop func()) {
+	if up == nil || down == nil {
+		panic("Programming error: (l *Logger) ShiftLevelOnSignal(): Passed nil as signal")
+	}
+	c := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(c, up, down)
+	go func() {
+		for {
+			select {
+			case sig := <-c:
+				delta := 1
+				if sig == down {
+					delta = -1
+				}
+				old := l.Level()
+				if new := shiftLevel(old, delta); new != old {
+					l.SetLevel(new)
+					l.Output(Record{Level: LevelAudit, Message: fmt.Sprintf("Loglevel changed from %s to %s by signal %s", old, new, sig)})
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(c)
+		close(done)
+	}
+}
+
+// notifyLevelChange calls the level change listeners if old and new differ.
+// The caller must not hold the lock of the Logger the listeners belong to.
+func notifyLevelChange(listeners []func(old, new Level), old, new Level) {
+	if old == new {
+		return
+	}
+	for _, fn := range listeners {
+		fn(old, new)
+	}
+}
+
+// shiftLevel returns the loglevel that is delta steps more verbose than level,
+// clamped to the range from LevelPanic to LevelTrace.
+func shiftLevel(level Level, delta int) Level {
+	shifted := int(level) + delta
+	if shifted < int(LevelPanic) {
+		return LevelPanic
+	}
+	if shifted > int(LevelTrace) {
+		return LevelTrace
+	}
+	return Level(shifted)
+}
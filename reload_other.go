@@ -0,0 +1,10 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build !unix
+
+package logger
+
+import "os"
+
+// defaultSignals is empty as SIGHUP only exists on unix systems, the signals have to be passed explicitly.
+var defaultSignals []os.Signal
@@ -0,0 +1,48 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"testing"
+)
+
+func TestReloadLevel(t *testing.T) {
+	var changes [][2]Level
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	l.OnLevelChange(func(old, new Level) { changes = append(changes, [2]Level{old, new}) })
+	t.Setenv("TEST_LOGLEVEL", "debug")
+	if err := l.ReloadLevel(EnvLevelSource("TEST_LOGLEVEL")); err != nil {
+		t.Fatal(err)
+	}
+	l.SetLevel(LevelDebug)
+	t.Setenv("TEST_LOGLEVEL", "verbose")
+	if err := l.ReloadLevel(EnvLevelSource("TEST_LOGLEVEL")); err == nil {
+		t.Error("Expected an error for an invalid loglevel")
+	}
+	if l.Level() != LevelDebug {
+		t.Errorf("Expected level %s, got level %s", LevelDebug, l.Level())
+	}
+	if len(changes) != 1 || changes[0] != [2]Level{LevelInfo, LevelDebug} {
+		t.Errorf("Unexpected level changes %v", changes)
+	}
+}
+
+func TestShiftLevel(t *testing.T) {
+	tests := []struct {
+		level    Level
+		delta    int
+		expected Level
+	}{
+		{LevelInfo, 1, LevelDebug},
+		{LevelInfo, -1, LevelNotice},
+		{LevelTrace, 1, LevelTrace},
+		{LevelPanic, -1, LevelPanic},
+		{Level(-100), 1, LevelPanic},
+	}
+	for _, test := range tests {
+		if got := shiftLevel(test.level, test.delta); got != test.expected {
+			t.Errorf("Expected %s. Got %s", test.expected, got)
+		}
+	}
+}
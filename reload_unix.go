@@ -0,0 +1,19 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build unix
+
+package logger
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultSignals holds the signals ReloadLevelOnSignal and ReopenOnSignal listen to if no signal is passed.
+var defaultSignals = []os.Signal{syscall.SIGHUP}
+
+// ShiftLevelOnSIGUSR makes the Logger more verbose on SIGUSR1 and less verbose on SIGUSR2,
+// see ShiftLevelOnSignal. Calling the returned function stops the shifting.
+func (l *Logger) ShiftLevelOnSIGUSR() (stop func()) {
+	return l.ShiftLevelOnSignal(syscall.SIGUSR1, syscall.SIGUSR2)
+}
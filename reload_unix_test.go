@@ -0,0 +1,98 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build unix
+
+package logger
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReloadLevelOnSignal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loglevel")
+	if err := os.WriteFile(path, []byte("warning\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changed := make(chan Level, 1)
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	l.OnLevelChange(func(old, new Level) { changed <- new })
+	stop := l.ReloadLevelOnSignal(FileLevelSource(path), syscall.SIGUSR1)
+	defer stop()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case lvl := <-changed:
+		if lvl != LevelWarning {
+			t.Errorf("Expected level %s, got level %s", LevelWarning, lvl)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Loglevel was not reloaded")
+	}
+}
+
+func TestReloadLevelOnSignalError(t *testing.T) {
+	out := new(syncBuilder)
+	l := New(out, LevelInfo, loglevelDelimiter)
+	l.SetErrorHandler(func(err error) {
+		l.Error("reload failed: ", err)
+	})
+	stop := l.ReloadLevelOnSignal(FileLevelSource(filepath.Join(t.TempDir(), "missing")), syscall.SIGUSR1)
+	defer stop()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.HasPrefix(out.String(), "[Error] - reload failed: ") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !strings.HasPrefix(out.String(), "[Error] - reload failed: ") {
+		t.Fatalf("The error handler's record was not written. Got %q", out.String())
+	}
+	withoutDeadlock(t, func() {
+		l.Info("still logging")
+	})
+}
+
+func TestShiftLevelOnSIGUSR(t *testing.T) {
+	out := new(syncBuilder)
+	changed := make(chan Level, 1)
+	l := New(out, LevelNotice, loglevelDelimiter)
+	l.OnLevelChange(func(old, new Level) { changed <- new })
+	stop := l.ShiftLevelOnSIGUSR()
+	defer stop()
+	for _, test := range []struct {
+		sig      syscall.Signal
+		expected Level
+	}{
+		{syscall.SIGUSR1, LevelInfo},
+		{syscall.SIGUSR2, LevelNotice},
+		{syscall.SIGUSR2, LevelWarning},
+	} {
+		if err := syscall.Kill(os.Getpid(), test.sig); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case lvl := <-changed:
+			if lvl != test.expected {
+				t.Errorf("Expected level %s, got level %s", test.expected, lvl)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Loglevel was not shifted")
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	expected := "[Audit] - Loglevel changed from Notice to Warning by signal user defined signal 2\n"
+	for !strings.HasSuffix(out.String(), expected) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !strings.HasSuffix(out.String(), expected) {
+		t.Errorf("Expected suffix %q. Got %q", expected, out.String())
+	}
+}
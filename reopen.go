@@ -0,0 +1,136 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// ReopenableFile is an io.WriteCloser that appends to the file at a path and can reopen that path, see Reopen.
+// It is meant for files that are rotated by an external tool like logrotate: after the tool renamed the file,
+// reopening creates a new file at the path instead of writing to the renamed one. A ReopenableFile can be used
+// by multiple goroutines and can be passed to New or (l *Logger) SetOutput.
+type ReopenableFile struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewReopenableFile opens or creates the file at path for appending.
+func NewReopenableFile(path string) (*ReopenableFile, error) {
+	f := &ReopenableFile{path: path}
+	file, err := f.open()
+	if err != nil {
+		return nil, err
+	}
+	f.file = file
+	return f, nil
+}
+
+// Close closes the underlying file. Writing to a closed ReopenableFile reopens it.
+func (f *ReopenableFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// Path returns the path of the file.
+func (f *ReopenableFile) Path() string {
+	return f.path
+}
+
+// Reopen closes the file and opens or creates the file at its path again. If the path cannot be opened,
+// Reopen returns an error and the ReopenableFile keeps writing to the previous file.
+func (f *ReopenableFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := f.open()
+	if err != nil {
+		return err
+	}
+	if f.file != nil {
+		err = f.file.Close()
+	}
+	f.file = file
+	return err
+}
+
+// Write appends p to the file.
+func (f *ReopenableFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		file, err := f.open()
+		if err != nil {
+			return 0, err
+		}
+		f.file = file
+	}
+	return f.file.Write(p)
+}
+
+// open opens the file at f.path for appending.
+func (f *ReopenableFile) open() (*os.File, error) {
+	return os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+}
+
+// Reopen flushes the Logger and reopens all of its writers that have a method Reopen() error, like ReopenableFile.
+// If reopening fails for more than one writer, the first error is returned.
+func (l *Logger) Reopen() error {
+	if err := l.Flush(); err != nil {
+		return err
+	}
+	l.lock()
+	defer l.unlock()
+	var err error
+	for _, w := range l.writers() {
+		r, ok := w.(interface{ Reopen() error })
+		if !ok {
+			continue
+		}
+		if rerr := r.Reopen(); rerr != nil && err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+// ReopenOnSignal calls (l *Logger) Reopen every time the process receives one of sigs, SIGHUP if no signal is passed.
+// Errors are passed to the Logger's error handler. Calling the returned function stops the reopening.
+// Passing no signal on a system without SIGHUP will cause a panic.
+func (l *Logger) ReopenOnSignal(sigs ...os.Signal) (stop func()) {
+	if len(sigs) < 1 {
+		sigs = defaultSignals
+	}
+	if len(sigs) < 1 {
+		panic("Programming error: (l *Logger) ReopenOnSignal(): Passed no signal on a system without SIGHUP")
+	}
+	c := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(c, sigs...)
+	go func() {
+		for {
+			select {
+			case <-c:
+				if err := l.Reopen(); err != nil {
+					l.lock()
+					l.handleError(err)
+					l.unlock()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(c)
+		close(done)
+	}
+}
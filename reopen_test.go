@@ -0,0 +1,43 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readFile returns the content of the file at path or fails the test.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestReopenableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := NewReopenableFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(f, LevelInfo, loglevelDelimiter)
+	defer l.Close()
+	l.Info("before rotation")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("after rotation")
+	if s := readFile(t, path+".1"); s != "[Info] - before rotation\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - before rotation\n", s)
+	}
+	if s := readFile(t, path); s != "[Info] - after rotation\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - after rotation\n", s)
+	}
+}
@@ -0,0 +1,45 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+//go:build unix
+
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReopenOnSignal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := NewReopenableFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(f, LevelInfo, loglevelDelimiter)
+	defer l.Close()
+	stop := l.ReopenOnSignal(syscall.SIGUSR1)
+	defer stop()
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		} else if !errors.Is(err, os.ErrNotExist) || time.Now().After(deadline) {
+			t.Fatalf("File was not reopened: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	l.Info("reopened")
+	if s := readFile(t, path); s != "[Info] - reopened\n" {
+		t.Errorf("Expected %q. Got %q", "[Info] - reopened\n", s)
+	}
+}
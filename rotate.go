@@ -0,0 +1,209 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the layout of the timestamp that is appended to the name of a rotated file.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// RotateOptions configures the rotation behaviour of a RotatingFile.
+type RotateOptions struct {
+	MaxSize    int64         // Rotate before the file would grow beyond MaxSize bytes, 0 disables size-based rotation.
+	Interval   time.Duration // Rotate if the file has been written to for longer than Interval, 0 disables time-based rotation.
+	MaxBackups int           // Maximum number of rotated files to keep, 0 keeps all of them.
+	Compress   bool          // Compress rotated files with gzip.
+}
+
+// RotatingFile is an io.WriteCloser that writes to a file and rotates it by size and/or age.
+// A rotated file is renamed to the file's path with a timestamp appended, e.g. "app.log.20230314T150926.000000000",
+// compressed files additionally get the suffix ".gz". A RotatingFile can be used by multiple goroutines
+// and can be passed to New or (l *Logger) SetOutput.
+type RotatingFile struct {
+	mu     sync.Mutex
+	path   string
+	opts   RotateOptions
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewRotatingFile opens or creates the file at path for appending and returns a RotatingFile that rotates it according to opts.
+func NewRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	if opts.MaxSize < 0 || opts.Interval < 0 || opts.MaxBackups < 0 {
+		return nil, errors.New("Rotation options must not be negative")
+	}
+	f := &RotatingFile{path: path, opts: opts}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Close closes the underlying file. Writing to a closed RotatingFile reopens it.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.close()
+}
+
+// Rotate rotates the file immediately.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rotate()
+}
+
+// Write writes p to the file. If p would exceed the maximum size or the rotation interval has passed,
+// the file is rotated first. p is never split across files.
+func (f *RotatingFile) Write(p []byte) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if f.due(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err = f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// due returns true if writing n more bytes requires a rotation.
+func (f *RotatingFile) due(n int64) bool {
+	if f.opts.MaxSize > 0 && f.size > 0 && f.size+n > f.opts.MaxSize {
+		return true
+	}
+	if f.opts.Interval > 0 && time.Since(f.opened) >= f.opts.Interval {
+		return true
+	}
+	return false
+}
+
+// open opens the file at f.path for appending. The interval of an existing file that is not empty
+// starts at its modification time, so restarting the program does not postpone the rotation.
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.opened = time.Now()
+	if f.size > 0 {
+		f.opened = info.ModTime()
+	}
+	return nil
+}
+
+// close closes the file if it is open.
+func (f *RotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// rotate renames the current file, opens a new one and takes care of compression and old backups.
+func (f *RotatingFile) rotate() error {
+	if err := f.close(); err != nil {
+		return err
+	}
+	backup := f.path + "." + time.Now().Format(backupTimeFormat)
+	if err := os.Rename(f.path, backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	if f.opts.Compress {
+		if err := compressFile(backup); err != nil {
+			return fmt.Errorf("Could not compress rotated file %q: %w", backup, err)
+		}
+	}
+	return f.removeBackups()
+}
+
+// backups returns the paths of all rotated files, oldest first.
+func (f *RotatingFile) backups() ([]string, error) {
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ts := strings.TrimSuffix(strings.TrimPrefix(m, f.path+"."), ".gz")
+		if _, err := time.Parse(backupTimeFormat, ts); err == nil {
+			backups = append(backups, m)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// removeBackups deletes the oldest rotated files that exceed the maximum number of backups.
+func (f *RotatingFile) removeBackups() error {
+	if f.opts.MaxBackups < 1 {
+		return nil
+	}
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > f.opts.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// compressFile compresses the file at path to path + ".gz" and removes the uncompressed file.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
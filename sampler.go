@@ -0,0 +1,81 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// Sampler decides whether a record is written. Samplers are used to limit the amount of records
+// a chatty part of a program can produce.
+type Sampler interface {
+	Sample(rec *Record) bool // Sample returns true if rec shall be written.
+}
+
+// firstNSampler lets the first n records per level and interval pass.
+type firstNSampler struct {
+	mu       sync.Mutex
+	n        int
+	interval time.Duration
+	start    map[Level]time.Time
+	count    map[Level]int
+}
+
+// NewFirstNSampler returns a Sampler that lets the first n records of each loglevel pass within every interval,
+// all further records of that loglevel are dropped until the interval has passed. The interval is measured by the
+// records' timestamps. NewFirstNSampler(100, time.Second) limits the output to 100 records per second and level.
+func NewFirstNSampler(n int, interval time.Duration) Sampler {
+	if n < 0 || interval <= 0 {
+		panic("Programming error: logger.NewFirstNSampler: Passed negative n or non-positive interval")
+	}
+	return &firstNSampler{n: n, interval: interval, start: make(map[Level]time.Time), count: make(map[Level]int)}
+}
+
+func (s *firstNSampler) Sample(rec *Record) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec.Time.Sub(s.start[rec.Level]) >= s.interval || rec.Time.Before(s.start[rec.Level]) {
+		s.start[rec.Level] = rec.Time
+		s.count[rec.Level] = 0
+	}
+	s.count[rec.Level]++
+	return s.count[rec.Level] <= s.n
+}
+
+// everyNSampler lets every nth record per level pass.
+type everyNSampler struct {
+	mu    sync.Mutex
+	n     uint64
+	count map[Level]uint64
+}
+
+// NewEveryNSampler returns a Sampler that lets 1 in n records of each loglevel pass, starting with the first one.
+func NewEveryNSampler(n int) Sampler {
+	if n < 1 {
+		panic("Programming error: logger.NewEveryNSampler: Passed n less than 1")
+	}
+	return &everyNSampler{n: uint64(n), count: make(map[Level]uint64)}
+}
+
+func (s *everyNSampler) Sample(rec *Record) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pass := s.count[rec.Level]%s.n == 0
+	s.count[rec.Level]++
+	return pass
+}
+
+// Sampler returns the Logger's sampler, nil means all records are written.
+func (l *Logger) Sampler() Sampler {
+	defer l.runlock(l.rlock())
+	return l.sampler
+}
+
+// SetSampler sets a Sampler that decides which of the records that pass the Logger's loglevel are written.
+// Dropped records are not passed to hooks. Passing nil removes the sampler.
+func (l *Logger) SetSampler(s Sampler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sampler = s
+}
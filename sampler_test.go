@@ -0,0 +1,38 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFirstNSampler(t *testing.T) {
+	start := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetSampler(NewFirstNSampler(2, time.Second))
+	for i := 0; i < 5; i++ {
+		l.Output(Record{Level: LevelDebug, Time: start.Add(time.Duration(i) * 100 * time.Millisecond), Message: "debug"})
+		l.Output(Record{Level: LevelInfo, Time: start, Message: "info"})
+	}
+	l.Output(Record{Level: LevelDebug, Time: start.Add(time.Second), Message: "next second"})
+	expect := "[Debug] - debug\n[Info] - info\n[Debug] - debug\n[Info] - info\n[Debug] - next second\n"
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
+
+func TestEveryNSampler(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetSampler(NewEveryNSampler(3))
+	for i := 0; i < 7; i++ {
+		l.Debugf("%d", i)
+	}
+	expect := "[Debug] - 0\n[Debug] - 3\n[Debug] - 6\n"
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
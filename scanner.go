@@ -0,0 +1,446 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScanLineSize is the length of the longest line a Scanner reads.
+const maxScanLineSize = 64 << 20
+
+// Scanner reads records written by a Logger back into Records. Successive calls to Scan step through the records.
+//
+// In FormatText the Scanner expects the default layout. The prefix cannot be told apart from the message and stays
+// part of it, field values are strings. Lines that do not start with a level tag are attached to the preceding
+// record as its stack trace, so messages must consist of a single line. In FormatCSV a stack trace stays part of
+// the message. Timestamps are parsed with the Scanner's time format.
+type Scanner struct {
+	format     Format
+	delimiter  string
+	timeFormat string
+	lines      *bufio.Scanner
+	json       *json.Decoder
+	csv        *csv.Reader
+	escaped    bool
+	line       int
+	pending    *string
+	rec        Record
+	err        error
+}
+
+// jsonScanRecord is the layout of a record written in FormatJSON, as it is read by a Scanner.
+type jsonScanRecord struct {
+	Level    string          `json:"level"`
+	Time     string          `json:"time"`
+	Hostname string          `json:"hostname"`
+	PID      int             `json:"pid"`
+	Caller   string          `json:"caller"`
+	Prefix   string          `json:"prefix"`
+	Message  string          `json:"message"`
+	Fields   json.RawMessage `json:"fields"`
+	Stack    string          `json:"stack"`
+}
+
+// NewScanner returns a Scanner that reads records in format from r. The Scanner expects the delimiter " - " and
+// no timestamps in FormatText and timestamps in time.RFC3339Nano in FormatJSON and FormatCSV,
+// see SetDelimiter and SetTimeFormat. Passing an invalid format will cause a panic.
+func NewScanner(r io.Reader, format Format) *Scanner {
+	if r == nil {
+		panic("Programming error: logger.NewScanner: Passed nil as reader")
+	}
+	assertFormat(format)
+	s := &Scanner{format: format, delimiter: " - "}
+	switch format {
+	case FormatJSON:
+		s.json = json.NewDecoder(r)
+		s.timeFormat = time.RFC3339Nano
+	case FormatCSV:
+		s.csv = csv.NewReader(r)
+		s.csv.FieldsPerRecord = 5
+		s.timeFormat = time.RFC3339Nano
+	default:
+		s.lines = bufio.NewScanner(r)
+		s.lines.Buffer(nil, maxScanLineSize)
+	}
+	return s
+}
+
+// Err returns the first error that occurred while scanning, except io.EOF.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// Record returns the record read by the last successful call to Scan.
+func (s *Scanner) Record() *Record {
+	return &s.rec
+}
+
+// Scan reads the next record, which is then available through Record. It returns false if there are
+// no more records or an error occurred, see Err.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	var err error
+	switch s.format {
+	case FormatJSON:
+		err = s.scanJSON()
+	case FormatCSV:
+		err = s.scanCSV()
+	default:
+		err = s.scanText()
+	}
+	if err != nil {
+		if err != io.EOF {
+			s.err = err
+		}
+		return false
+	}
+	return true
+}
+
+// SetDelimiter sets the delimiter of records in FormatText. Passing an empty delimiter will cause a panic.
+func (s *Scanner) SetDelimiter(delimiter string) {
+	if len(delimiter) < 1 {
+		panic("Programming error: (s *Scanner) SetDelimiter(): Passed empty string as delimiter")
+	}
+	s.delimiter = delimiter
+}
+
+// SetEscaped sets whether records in FormatText were written by a Logger that escapes its delimiter,
+// see SetEscapeDelimiter. The segments of such records are split unambiguously.
+func (s *Scanner) SetEscaped(escaped bool) {
+	s.escaped = escaped
+}
+
+// SetTimeFormat sets the layout the timestamps were written with. In FormatText an empty format means
+// that the records have no timestamps. Timestamps without time zone are parsed in the local time zone.
+func (s *Scanner) SetTimeFormat(format string) {
+	s.timeFormat = format
+}
+
+// scanCSV reads the next record in FormatCSV.
+func (s *Scanner) scanCSV() error {
+	cols, err := s.csv.Read()
+	if err != nil {
+		return err
+	}
+	line, _ := s.csv.FieldPos(0)
+	rec := Record{Prefix: cols[2], Message: cols[3]}
+	if rec.Level, err = ParseLevel(cols[1]); err != nil {
+		return fmt.Errorf("Line %d: %w", line, err)
+	}
+	if rec.Time, err = s.parseTime(cols[0]); err != nil {
+		return fmt.Errorf("Line %d: %w", line, err)
+	}
+	if len(cols[4]) > 0 {
+		var ok bool
+		if rec.Fields, ok = parseTextFields(cols[4]); !ok {
+			return fmt.Errorf("Line %d: Malformed fields %q", line, cols[4])
+		}
+	}
+	s.rec = rec
+	return nil
+}
+
+// scanJSON reads the next record in FormatJSON.
+func (s *Scanner) scanJSON() error {
+	var jrec jsonScanRecord
+	if err := s.json.Decode(&jrec); err != nil {
+		return err
+	}
+	rec := Record{Prefix: jrec.Prefix, Message: jrec.Message, Stack: jrec.Stack}
+	var err error
+	if rec.Level, err = ParseLevel(jrec.Level); err != nil {
+		return err
+	}
+	if rec.Time, err = s.parseTime(jrec.Time); err != nil {
+		return err
+	}
+	if len(jrec.Caller) > 0 {
+		if rec.Caller.File, rec.Caller.Line, err = parseCaller(jrec.Caller); err != nil {
+			return err
+		}
+	}
+	if len(jrec.Hostname) > 0 {
+		rec.Fields = append(rec.Fields, Field{Key: "hostname", Value: jrec.Hostname})
+	}
+	if jrec.PID != 0 {
+		rec.Fields = append(rec.Fields, Field{Key: "pid", Value: jrec.PID})
+	}
+	fields, err := parseJSONFields(jrec.Fields)
+	if err != nil {
+		return err
+	}
+	rec.Fields = append(rec.Fields, fields...)
+	s.rec = rec
+	return nil
+}
+
+// scanText reads the next record in FormatText and the stack trace that follows it.
+func (s *Scanner) scanText() error {
+	var line string
+	for len(line) < 1 {
+		var err error
+		if line, err = s.readLine(); err != nil {
+			return err
+		}
+	}
+	rec, err := s.parseText(line)
+	if err != nil {
+		return fmt.Errorf("Line %d: %w", s.line, err)
+	}
+	var stack []string
+	for {
+		next, err := s.readLine()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if _, ok := textLevel(next); ok {
+			s.pending = &next
+			break
+		}
+		stack = append(stack, next)
+	}
+	rec.Stack = strings.TrimRight(strings.Join(stack, "\n"), "\n")
+	s.rec = rec
+	return nil
+}
+
+// readLine returns the next line of a text stream, io.EOF at its end.
+func (s *Scanner) readLine() (string, error) {
+	if s.pending != nil {
+		line := *s.pending
+		s.pending = nil
+		return line, nil
+	}
+	if !s.lines.Scan() {
+		if err := s.lines.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	s.line++
+	return s.lines.Text(), nil
+}
+
+// parseText parses a record in FormatText rendered with the default layout.
+func (s *Scanner) parseText(line string) (Record, error) {
+	var rec Record
+	level, ok := textLevel(line)
+	if !ok {
+		return rec, errors.New("Line does not start with a level tag")
+	}
+	rec.Level = level
+	line = stripANSI(line)
+	if s.escaped {
+		return s.parseEscapedText(rec, line)
+	}
+	rest := line[strings.IndexByte(line, ']')+1:]
+	if len(rest) < 1 {
+		return rec, nil
+	}
+	if !strings.HasPrefix(rest, s.delimiter) {
+		return rec, errors.New("Level tag is not followed by the delimiter")
+	}
+	rest = rest[len(s.delimiter):]
+	if len(s.timeFormat) > 0 {
+		ts, after, _ := strings.Cut(rest, s.delimiter)
+		var err error
+		if rec.Time, err = s.parseTime(ts); err != nil {
+			return rec, err
+		}
+		rest = after
+	}
+	if seg, after, ok := strings.Cut(rest, s.delimiter); ok {
+		if file, line, err := parseCaller(seg); err == nil {
+			rec.Caller.File, rec.Caller.Line = file, line
+			rest = after
+		}
+	}
+	if i := strings.LastIndex(rest, s.delimiter); i >= 0 {
+		if fields, ok := parseTextFields(rest[i+len(s.delimiter):]); ok {
+			rec.Fields = fields
+			rest = rest[:i]
+		}
+	}
+	rec.Message = rest
+	return rec, nil
+}
+
+// parseEscapedText parses the segments following the level tag of a record in FormatText that was written by
+// a Logger that escapes its delimiter.
+func (s *Scanner) parseEscapedText(rec Record, line string) (Record, error) {
+	segs := SplitRecord(line, s.delimiter)[1:]
+	if len(segs) < 1 {
+		return rec, nil
+	}
+	if len(s.timeFormat) > 0 {
+		var err error
+		if rec.Time, err = s.parseTime(segs[0]); err != nil {
+			return rec, err
+		}
+		segs = segs[1:]
+	}
+	if len(segs) > 1 {
+		if file, line, err := parseCaller(segs[0]); err == nil {
+			rec.Caller.File, rec.Caller.Line = file, line
+			segs = segs[1:]
+		}
+	}
+	switch len(segs) {
+	case 0:
+	case 1:
+		rec.Message = segs[0]
+	case 2:
+		fields, ok := parseTextFields(segs[1])
+		if !ok {
+			return rec, fmt.Errorf("Malformed fields %q", segs[1])
+		}
+		rec.Message, rec.Fields = segs[0], fields
+	default:
+		return rec, errors.New("Record has too many segments")
+	}
+	return rec, nil
+}
+
+// parseTime parses a timestamp with the Scanner's time format, an empty timestamp results in the zero time.
+func (s *Scanner) parseTime(ts string) (time.Time, error) {
+	if len(ts) < 1 {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(s.timeFormat, ts, time.Local)
+}
+
+// textLevel returns the level of line if it starts with a level tag.
+func textLevel(line string) (Level, bool) {
+	line = stripANSI(line)
+	if !strings.HasPrefix(line, "[") {
+		return LevelInvalid, false
+	}
+	end := strings.IndexByte(line, ']')
+	if end < 0 {
+		return LevelInvalid, false
+	}
+	level, err := ParseLevel(line[1:end])
+	return level, err == nil
+}
+
+// stripANSI removes the ANSI color sequences of a colored Logger from s.
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b[") {
+		return s
+	}
+	b := new(strings.Builder)
+	for {
+		i := strings.Index(s, "\x1b[")
+		if i < 0 {
+			break
+		}
+		b.WriteString(s[:i])
+		end := strings.IndexByte(s[i:], 'm')
+		if end < 0 {
+			s = s[i:]
+			break
+		}
+		s = s[i+end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// parseCaller parses a caller rendered as "file:line".
+func parseCaller(s string) (file string, line int, err error) {
+	i := strings.LastIndexByte(s, ':')
+	if i < 1 || strings.ContainsAny(s, " \t") {
+		return "", 0, fmt.Errorf("Malformed caller %q", s)
+	}
+	if line, err = strconv.Atoi(s[i+1:]); err != nil || line < 1 {
+		return "", 0, fmt.Errorf("Malformed caller %q", s)
+	}
+	return s[:i], line, nil
+}
+
+// parseTextFields parses a space separated list of key=value pairs as written by appendTextFields.
+// ok is false if s is not such a list.
+func parseTextFields(s string) ([]Field, bool) {
+	var fields []Field
+	for len(s) > 0 {
+		key, rest, ok := cutTextToken(s)
+		if !ok || !strings.HasPrefix(rest, "=") {
+			return nil, false
+		}
+		value, rest, ok := cutTextToken(rest[1:])
+		if !ok {
+			return nil, false
+		}
+		fields = append(fields, Field{Key: key, Value: value})
+		if len(rest) > 0 {
+			if rest[0] != ' ' || len(rest) < 2 {
+				return nil, false
+			}
+			rest = rest[1:]
+		}
+		s = rest
+	}
+	return fields, len(fields) > 0
+}
+
+// cutTextToken returns the key or value at the start of s, unquoted if it is quoted, and the rest of s.
+func cutTextToken(s string) (token, rest string, ok bool) {
+	if strings.HasPrefix(s, `"`) {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", "", false
+		}
+		if token, err = strconv.Unquote(quoted); err != nil {
+			return "", "", false
+		}
+		return token, s[len(quoted):], true
+	}
+	end := strings.IndexAny(s, "= \"")
+	if end < 0 {
+		end = len(s)
+	}
+	if end == 0 || (end < len(s) && s[end] == '"') {
+		return "", "", false
+	}
+	return s[:end], s[end:], true
+}
+
+// parseJSONFields decodes the fields object of a record in FormatJSON, preserving the order of the fields.
+func parseJSONFields(raw json.RawMessage) ([]Field, error) {
+	if len(raw) < 1 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("Record fields are not a JSON object")
+	}
+	var fields []Field
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, Field{Key: key, Value: value})
+	}
+	return fields, nil
+}
@@ -0,0 +1,77 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanner(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.Local)
+	for _, format := range []Format{FormatText, FormatJSON, FormatCSV} {
+		out := new(strings.Builder)
+		l := New(out, LevelInfo, loglevelDelimiter)
+		l.SetFormat(format)
+		l.SetTimeFormat(time.RFC3339)
+		l.SetClock(func() time.Time { return now })
+		l.Info("plain - message")
+		l.InfoKV("with fields", "user", "bob smith", "empty", "")
+		l.SetStacktraceLevel(LevelError)
+		l.Error("failed")
+		s := NewScanner(strings.NewReader(out.String()), format)
+		s.SetTimeFormat(time.RFC3339)
+		var recs []Record
+		for s.Scan() {
+			recs = append(recs, *s.Record())
+		}
+		if err := s.Err(); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if len(recs) != 3 {
+			t.Fatalf("%s: Expected 3 records. Got %d", format, len(recs))
+		}
+		if !recs[0].Time.Equal(now) || recs[0].Level != LevelInfo || recs[0].Message != "plain - message" {
+			t.Errorf("%s: Unexpected record %+v", format, recs[0])
+		}
+		expected := []Field{{"user", "bob smith"}, {"empty", ""}}
+		if recs[1].Message != "with fields" || !reflect.DeepEqual(recs[1].Fields, expected) {
+			t.Errorf("%s: Unexpected record %+v", format, recs[1])
+		}
+		if recs[2].Level != LevelError || !strings.HasPrefix(recs[2].Message, "failed") {
+			t.Errorf("%s: Unexpected record %+v", format, recs[2])
+		}
+		if format != FormatCSV && !strings.Contains(recs[2].Stack, "TestScanner") {
+			t.Errorf("%s: Expected a stack trace. Got %q", format, recs[2].Stack)
+		}
+	}
+}
+
+func TestScannerText(t *testing.T) {
+	input := "\x1b[31m[Error]\x1b[0m | main.go:12 | db: timeout | retries=3\n\n[Info]\nnot a record\n"
+	s := NewScanner(strings.NewReader(input), FormatText)
+	s.SetDelimiter(" | ")
+	if !s.Scan() {
+		t.Fatal(s.Err())
+	}
+	rec := s.Record()
+	if rec.Level != LevelError || rec.Caller.File != "main.go" || rec.Caller.Line != 12 ||
+		rec.Message != "db: timeout" || !reflect.DeepEqual(rec.Fields, []Field{{"retries", "3"}}) || rec.Stack != "" {
+		t.Errorf("Unexpected record %+v", rec)
+	}
+	if !s.Scan() {
+		t.Fatal(s.Err())
+	}
+	if rec = s.Record(); rec.Level != LevelInfo || rec.Stack != "not a record" {
+		t.Errorf("Unexpected record %+v", rec)
+	}
+	if s.Scan() || s.Err() != nil {
+		t.Errorf("Expected the end of input. Got %v", s.Err())
+	}
+	s = NewScanner(strings.NewReader("garbage\n"), FormatText)
+	if s.Scan() || s.Err() == nil {
+		t.Error("Expected an error for a line without level tag")
+	}
+}
@@ -0,0 +1,148 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	SecretMask   SecretPolicy = iota //Replace the values of secret fields by "****".
+	SecretHash                       //Replace the values of secret fields by a SHA-256 hash, e.g. "sha256:9f86d081884c7d65". Equal values can be correlated without revealing them.
+	SecretDrop                       //Remove secret fields from the records.
+	SecretReveal                     //Write the values of secret fields as they are, for development environments.
+)
+
+// secretMask replaces the values of secret fields by SecretMask.
+const secretMask = "****"
+
+// Represents the way a Logger treats the values of fields created by Secret.
+type SecretPolicy int
+
+// secretValue marks the value of a field as sensitive.
+type secretValue struct {
+	v any
+}
+
+// Secret returns a field whose value is sensitive, like a password or a personal data record. The Logger's
+// SecretPolicy decides whether the value is masked, hashed, dropped or written as is. Secret fields can be passed
+// in place of a key/value pair to the KV methods:
+//
+//	l.InfoKV("user created", "name", name, logger.Secret("email", email))
+func Secret(key string, value any) Field {
+	return Field{Key: key, Value: secretValue{v: value}}
+}
+
+// String masks the value, so a secret value that escapes the Logger's policy is not revealed.
+func (s secretValue) String() string {
+	return secretMask
+}
+
+// Panics if the secret policy does not exist.
+func assertSecretPolicy(p SecretPolicy) {
+	if err := checkSecretPolicy(p); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Returns an error if the secret policy does not exist.
+func checkSecretPolicy(p SecretPolicy) error {
+	if p < SecretMask || p > SecretReveal {
+		return fmt.Errorf("Secret policy %d is not defined", p)
+	}
+	return nil
+}
+
+// ParseSecretPolicy returns the SecretPolicy whose string representation matches input, ignoring case.
+// On failure, it returns SecretMask and an error.
+func ParseSecretPolicy(input string) (SecretPolicy, error) {
+	for p := SecretMask; p <= SecretReveal; p++ {
+		if strings.EqualFold(p.String(), input) {
+			return p, nil
+		}
+	}
+	return SecretMask, fmt.Errorf("Input sequence %q cannot be associated with a defined secret policy", input)
+}
+
+// String returns the string representation of a SecretPolicy. If the SecretPolicy is
+// not defined, String returns "Undefined".
+func (p SecretPolicy) String() string {
+	switch p {
+	case SecretMask:
+		return "Mask"
+	case SecretHash:
+		return "Hash"
+	case SecretDrop:
+		return "Drop"
+	case SecretReveal:
+		return "Reveal"
+	}
+	return "Undefined"
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the string representation of the SecretPolicy,
+// or an error if the SecretPolicy is not defined.
+func (p SecretPolicy) MarshalText() ([]byte, error) {
+	if err := checkSecretPolicy(p); err != nil {
+		return nil, err
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all input ParseSecretPolicy accepts.
+func (p *SecretPolicy) UnmarshalText(text []byte) error {
+	policy, err := ParseSecretPolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
+
+// SecretPolicy returns the way the Logger treats the values of secret fields.
+func (l *Logger) SecretPolicy() SecretPolicy {
+	defer l.runlock(l.rlock())
+	return l.secretPolicy
+}
+
+// SetSecretPolicy sets the way the Logger treats the values of fields created by Secret. The default is SecretMask.
+// Setting an invalid policy will cause a panic.
+func (l *Logger) SetSecretPolicy(p SecretPolicy) {
+	assertSecretPolicy(p)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.secretPolicy = p
+}
+
+// applySecretPolicy replaces the secret fields of rec according to the Logger's secret policy. The fields are
+// copied before they are modified. The caller must hold the Logger's lock.
+func (l *Logger) applySecretPolicy(rec *Record) {
+	var fields []Field
+	for i, f := range rec.Fields {
+		secret, ok := f.Value.(secretValue)
+		if !ok {
+			if fields != nil {
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if fields == nil {
+			fields = append(make([]Field, 0, len(rec.Fields)), rec.Fields[:i]...)
+		}
+		switch l.secretPolicy {
+		case SecretMask:
+			fields = append(fields, Field{Key: f.Key, Value: secretMask})
+		case SecretHash:
+			sum := sha256.Sum256([]byte(fieldValueString(secret.v)))
+			fields = append(fields, Field{Key: f.Key, Value: "sha256:" + hex.EncodeToString(sum[:8])})
+		case SecretReveal:
+			fields = append(fields, Field{Key: f.Key, Value: secret.v})
+		}
+	}
+	if fields != nil {
+		rec.Fields = fields
+	}
+}
@@ -0,0 +1,41 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretPolicy(t *testing.T) {
+	tests := []struct {
+		policy SecretPolicy
+		want   string
+	}{
+		{SecretMask, "[Info] - login - user=bob password=**** attempt=1\n"},
+		{SecretHash, "[Info] - login - user=bob password=sha256:f52fbd32b2b3b86f attempt=1\n"},
+		{SecretDrop, "[Info] - login - user=bob attempt=1\n"},
+		{SecretReveal, "[Info] - login - user=bob password=hunter2 attempt=1\n"},
+	}
+	for _, test := range tests {
+		b := new(strings.Builder)
+		l := New(b, LevelInfo, loglevelDelimiter)
+		l.SetSecretPolicy(test.policy)
+		if l.SecretPolicy() != test.policy {
+			t.Errorf("Expected policy %s. Got %s", test.policy, l.SecretPolicy())
+		}
+		l.InfoKV("login", "user", "bob", Secret("password", "hunter2"), "attempt", 1)
+		if b.String() != test.want {
+			t.Errorf("%s: Expected %q. Got %q", test.policy, test.want, b.String())
+		}
+	}
+}
+
+func TestSecretString(t *testing.T) {
+	if s := Secret("password", "hunter2").String(); s != "password=****" {
+		t.Errorf("Expected %q. Got %q", "password=****", s)
+	}
+	if p, err := ParseSecretPolicy("HASH"); err != nil || p != SecretHash {
+		t.Errorf("Expected policy %s, got policy %s and error %v", SecretHash, p, err)
+	}
+}
@@ -0,0 +1,256 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// shardCapacity is the number of records a shard of a sharded Logger buffers before the goroutines
+// sending records to it wait for the next merge.
+const shardCapacity = 1024
+
+// shardQueue holds the buffers of a sharded Logger until its background goroutine merges and writes them.
+type shardQueue struct {
+	shards   []recordShard
+	seq      atomic.Uint64 // Orders the records across all shards.
+	settings atomic.Pointer[shardSettings]
+	interval time.Duration
+	wake     chan struct{} // Requests a merge before the next interval because a shard is full.
+	flush    chan chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// shardSettings holds a snapshot of the settings a sharded Logger needs to prepare a record, so goroutines
+// sending records don't have to take the Logger's lock. It is replaced whenever one of the settings changes.
+type shardSettings struct {
+	reportCaller    bool
+	callerSkip      int
+	stacktraceLevel Level
+	extractor       ContextExtractor
+}
+
+// recordShard is a buffer of a sharded Logger.
+type recordShard struct {
+	mu     sync.Mutex
+	space  sync.Cond // Signaled when the shard has been emptied or closed.
+	closed bool
+	recs   []shardedRecord
+	_      [64]byte // Keeps neighbouring shards off the same cache line.
+}
+
+// shardedRecord is a record buffered by a sharded Logger together with its sequence number.
+type shardedRecord struct {
+	seq uint64
+	rec Record
+}
+
+// NewSharded constructs a new sharded Logger. It behaves like a Logger constructed by New, but instead of writing
+// a record under the Logger's lock, it appends the record to one of shards buffers, each with its own lock, so concurrent
+// goroutines rarely wait for each other. A background goroutine merges the buffers every interval, restores the order
+// in which the records were sent and writes them without holding a lock the sending goroutines wait for. A goroutine
+// only waits if the buffer it picked is full. Hooks, the sampler and the deduplication are applied when the records
+// are merged, the context extractor is called without the Logger's lock. As records are written after the print method has returned, write errors are not returned to the caller,
+// use (l *Logger) SetErrorHandler to receive them. Call Flush to wait until all buffered records have been written and
+// Close to stop the background goroutine.
+func NewSharded(w io.Writer, level Level, delimiter string, shards int, interval time.Duration) *Logger {
+	if shards < 1 {
+		panic("Programming error: logger.NewSharded: Passed a shard count less than 1")
+	}
+	if interval <= 0 {
+		panic("Programming error: logger.NewSharded: Passed a non-positive interval")
+	}
+	l := New(w, level, delimiter)
+	l.startShards(shards, interval)
+	return l
+}
+
+// startShards makes the Logger sharded with the given number of shards that are merged every interval
+// and starts its background goroutine.
+func (l *Logger) startShards(shards int, interval time.Duration) {
+	q := &shardQueue{
+		shards:   make([]recordShard, shards),
+		interval: interval,
+		wake:     make(chan struct{}, 1),
+		flush:    make(chan chan struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for i := range q.shards {
+		s := &q.shards[i]
+		s.space.L = &s.mu
+		s.recs = make([]shardedRecord, 0, shardCapacity)
+	}
+	l.mu.Lock()
+	l.shards = q
+	l.snapshotShards()
+	l.mu.Unlock()
+	go l.shardWorker()
+}
+
+// snapshotShards replaces the snapshot of the settings a sharded Logger needs to prepare a record.
+// It does nothing if the Logger is not sharded. The caller must hold the Logger's lock.
+func (l *Logger) snapshotShards() {
+	if l.shards == nil {
+		return
+	}
+	l.shards.settings.Store(&shardSettings{
+		reportCaller:    l.reportCaller,
+		callerSkip:      l.callerSkip,
+		stacktraceLevel: l.stacktraceLevel,
+		extractor:       l.extractor,
+	})
+}
+
+// shard buffers rec in one of the shards of a sharded Logger.
+func (l *Logger) shard(rec Record) (n int, written bool, err error) {
+	if !l.trigger(rec.Level) && !l.recording.Load() {
+		return 0, false, nil
+	}
+	q := l.shards
+	l.prepareShard(&rec, q.settings.Load())
+	s := &q.shards[rand.Intn(len(q.shards))]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Records sent by hooks or writers while the shards are merged must not wait for the merge.
+	for len(s.recs) >= shardCapacity && !s.closed && !l.reentrant() {
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+		s.space.Wait()
+	}
+	if s.closed {
+		l.dropped.Add(1)
+		return 0, false, ErrClosed
+	}
+	s.recs = append(s.recs, shardedRecord{seq: q.seq.Add(1), rec: rec})
+	return 0, true, nil
+}
+
+// prepareShard prepares rec like prepare, but reads the settings from the snapshot set instead of the Logger,
+// and attaches a stack trace if necessary. It does not need the Logger's lock.
+func (l *Logger) prepareShard(rec *Record, set *shardSettings) {
+	if rec.Time.IsZero() {
+		rec.Time = l.now()
+	}
+	l.applyScope(rec)
+	if rec.ctx != nil && set.extractor != nil {
+		rec.Fields = append(rec.Fields[:len(rec.Fields):len(rec.Fields)], set.extractor(rec.ctx)...)
+	}
+	rec.ctx = nil
+	resolveFields(rec)
+	caller := set.reportCaller && !rec.HasCaller()
+	stack := len(rec.Stack) < 1 && (rec.trace || set.stacktraceLevel != LevelInvalid && rec.Level <= set.stacktraceLevel)
+	if !caller && !stack {
+		return
+	}
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(2, pcs[:])
+	if caller {
+		rec.Caller = callerOf(pcs[:n], set.callerSkip)
+	}
+	if stack {
+		rec.Stack = stacktraceOf(pcs[:n], set.callerSkip)
+	}
+}
+
+// flushShards blocks until all records buffered by a sharded Logger before the call have been written.
+func (l *Logger) flushShards() error {
+	flushed := make(chan struct{})
+	select {
+	case l.shards.flush <- flushed:
+		<-flushed
+		return nil
+	case <-l.shards.done:
+		return ErrClosed
+	}
+}
+
+// closeShards writes all buffered records and stops the background goroutine of a sharded Logger.
+// Records sent to the Logger afterwards are discarded.
+func (l *Logger) closeShards() {
+	for i := range l.shards.shards {
+		s := &l.shards.shards[i]
+		s.mu.Lock()
+		s.closed = true
+		s.space.Broadcast()
+		s.mu.Unlock()
+	}
+	close(l.shards.stop)
+	<-l.shards.done
+}
+
+// shardWorker merges and writes the buffered records of a sharded Logger until it is closed.
+func (l *Logger) shardWorker() {
+	ticker := time.NewTicker(l.shards.interval)
+	defer ticker.Stop()
+	var buf []shardedRecord
+	for {
+		select {
+		case <-ticker.C:
+			buf = l.mergeShards(buf)
+		case <-l.shards.wake:
+			buf = l.mergeShards(buf)
+		case flushed := <-l.shards.flush:
+			buf = l.mergeShards(buf)
+			close(flushed)
+		case <-l.shards.stop:
+			l.mergeShards(buf)
+			close(l.shards.done)
+			return
+		}
+	}
+}
+
+// mergeShards takes the records of all shards, sorts them by their sequence number and writes them.
+// The shards are unlocked before the records are written. buf is reused to hold the records and returned
+// for the next merge.
+func (l *Logger) mergeShards(buf []shardedRecord) []shardedRecord {
+	buf = buf[:0]
+	for i := range l.shards.shards {
+		l.shards.shards[i].mu.Lock()
+	}
+	for i := range l.shards.shards {
+		s := &l.shards.shards[i]
+		buf = append(buf, s.recs...)
+		clear(s.recs)
+		s.recs = s.recs[:0]
+		s.space.Broadcast()
+		s.mu.Unlock()
+	}
+	if len(buf) < 1 {
+		return buf
+	}
+	sort.Slice(buf, func(i, j int) bool { return buf[i].seq < buf[j].seq })
+	var recs []*Record
+	l.lock()
+	defer l.unlock()
+	for i := range buf {
+		rec := &buf[i].rec
+		if l.sampler != nil && rec.Level != LevelAudit && !l.sampler.Sample(rec) {
+			continue
+		}
+		var summary *Record
+		var hookErr error
+		recs, summary, hookErr = l.process(rec, recs[:0])
+		if summary != nil {
+			l.write(summary)
+		}
+		for _, r := range recs {
+			l.write(r)
+		}
+		if hookErr != nil {
+			l.handleError(hookErr)
+		}
+	}
+	clear(buf)
+	return buf
+}
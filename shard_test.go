@@ -0,0 +1,115 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSharded(t *testing.T) {
+	const goroutines = 8
+	const records = 1000
+	b := new(bytes.Buffer)
+	l := NewSharded(b, LevelDebug, loglevelDelimiter, 4, time.Millisecond)
+	wg := new(sync.WaitGroup)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(id int) {
+			for j := 0; j < records; j++ {
+				l.Debugf("Goroutine %02d: Message %04d", id, j)
+			}
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	next := make([]int, goroutines)
+	scanner := bufio.NewScanner(bytes.NewReader(b.Bytes()))
+	for scanner.Scan() {
+		var id, j int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(scanner.Text(), "[Debug]"+loglevelDelimiter), "Goroutine %02d: Message %04d", &id, &j); err != nil {
+			t.Fatalf("Unexpected record %q: %s", scanner.Text(), err)
+		}
+		if j != next[id] {
+			t.Fatalf("Goroutine %d: Expected message %d. Got %d", id, next[id], j)
+		}
+		next[id]++
+	}
+	for id, n := range next {
+		if n != records {
+			t.Errorf("Goroutine %d: Expected %d records, got %d", id, records, n)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Info("after close"); err != ErrClosed || l.Dropped() != 1 {
+		t.Errorf("Expected %v, got %v", ErrClosed, err)
+	}
+	if err := l.Flush(); err != ErrClosed {
+		t.Errorf("Expected %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestShardedOrder(t *testing.T) {
+	b := new(bytes.Buffer)
+	l := NewSharded(b, LevelInfo, loglevelDelimiter, 3, time.Hour)
+	expect := new(bytes.Buffer)
+	for i := 0; i < 100; i++ {
+		l.Infof("%d", i)
+		fmt.Fprintf(expect, "[Info]%s%d\n", loglevelDelimiter, i)
+	}
+	l.Debug("filtered")
+	l.Close()
+	if b.String() != expect.String() {
+		t.Errorf("Records were not written in order: %q", b.String())
+	}
+}
+
+func TestShardedSlowWriter(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	l := NewSharded(w, LevelInfo, loglevelDelimiter, 2, time.Hour)
+	l.Info("first")
+	flushed := make(chan error)
+	go func() { flushed <- l.Flush() }()
+	// Give the background goroutine time to block in the writer.
+	time.Sleep(10 * time.Millisecond)
+	withoutDeadlock(t, func() { l.Info("second") })
+	close(w.release)
+	if err := <-flushed; err != nil {
+		t.Fatal(err)
+	}
+	l.Close()
+	expect := "[Info]" + loglevelDelimiter + "first\n[Info]" + loglevelDelimiter + "second\n"
+	if w.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, w.String())
+	}
+}
+
+func TestShardedCapacity(t *testing.T) {
+	b := new(syncBuilder)
+	l := NewSharded(b, LevelInfo, loglevelDelimiter, 1, time.Hour)
+	withoutDeadlock(t, func() {
+		for i := 0; i < 3*shardCapacity; i++ {
+			l.Infof("%d", i)
+		}
+	})
+	s := &l.shards.shards[0]
+	s.mu.Lock()
+	if n := len(s.recs); n > shardCapacity {
+		t.Errorf("Expected at most %d buffered records. Got %d", shardCapacity, n)
+	}
+	s.mu.Unlock()
+	l.Close()
+	if n := strings.Count(b.String(), "\n"); n != 3*shardCapacity {
+		t.Errorf("Expected %d records. Got %d", 3*shardCapacity, n)
+	}
+}
@@ -0,0 +1,46 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// Sink is a destination that receives records instead of rendered text, it is used for backends that
+// have their own record format like journald.
+type Sink interface {
+	WriteRecord(rec *Record) error // WriteRecord delivers rec to the destination, rec must not be modified.
+}
+
+// sinkOutput is a Sink added to a Logger.
+type sinkOutput struct {
+	s     Sink
+	level Level // Least severe loglevel written to s.
+}
+
+// AddSink adds s as an additional destination for the Logger's records. s will only receive records that are
+// as severe as or more severe than minLevel. Errors of s are handled by the Logger's error handler.
+// Setting an invalid loglevel will cause a panic.
+func (l *Logger) AddSink(s Sink, minLevel Level) {
+	if s == nil {
+		panic("Programming error: (l *Logger) AddSink(): Passed nil as sink")
+	}
+	assertLoglevel(minLevel)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, sinkOutput{s: s, level: minLevel})
+}
+
+// writeSinks passes rec to all sinks that accept its level. A failing sink does not keep rec from being passed
+// to the others, the first error is returned. The caller must hold the Logger's lock.
+func (l *Logger) writeSinks(rec *Record) error {
+	var err error
+	for _, so := range l.sinks {
+		if rec.Level > so.level {
+			continue
+		}
+		if serr := so.s.WriteRecord(rec); serr != nil {
+			l.handleError(serr)
+			if err == nil {
+				err = serr
+			}
+		}
+	}
+	return err
+}
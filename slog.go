@@ -0,0 +1,143 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+// SlogHandler implements slog.Handler. It routes records of the standard library's structured logger
+// through a Logger, therefore they are subject to the Logger's level filtering and output settings.
+// Attributes are written as fields, attributes inside groups get their keys prefixed by the group names
+// separated by dots.
+type SlogHandler struct {
+	l      *Logger
+	fields []Field
+	prefix string
+}
+
+// NewSlogHandler returns a slog.Handler that writes its records to l.
+func NewSlogHandler(l *Logger) *SlogHandler {
+	if l == nil {
+		panic("Programming error: logger.NewSlogHandler: Passed nil as Logger")
+	}
+	return &SlogHandler{l: l}
+}
+
+// LevelFromSlog maps a slog.Level to the loglevel of equal or next higher severity. Levels below slog.LevelDebug map to LevelTrace.
+// Levels between slog.LevelWarn and slog.LevelError map to LevelWarning, levels above slog.LevelError
+// map to LevelCritical, LevelAlert and LevelPanic in steps of 4.
+func LevelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return LevelTrace
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelInfo+2:
+		return LevelInfo
+	case level < slog.LevelWarn:
+		return LevelNotice
+	case level < slog.LevelError:
+		return LevelWarning
+	case level < slog.LevelError+4:
+		return LevelError
+	case level < slog.LevelError+8:
+		return LevelCritical
+	case level < slog.LevelError+12:
+		return LevelAlert
+	}
+	return LevelPanic
+}
+
+// SlogLevel maps a loglevel to a slog.Level, it is the inverse of LevelFromSlog.
+// Passing an invalid loglevel will cause a panic.
+func SlogLevel(level Level) slog.Level {
+	assertLoglevel(level)
+	switch {
+	case level > LevelDebug:
+		return slog.LevelDebug - 4*slog.Level(level-LevelDebug)
+	case level < LevelPanic:
+		return slog.LevelError + 12
+	}
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelNotice:
+		return slog.LevelInfo + 2
+	case LevelWarning:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	case LevelCritical:
+		return slog.LevelError + 4
+	case LevelAlert:
+		return slog.LevelError + 8
+	}
+	return slog.LevelError + 12
+}
+
+// Enabled reports whether the underlying Logger prints records of the given level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return LevelFromSlog(level) <= h.l.Level()
+}
+
+// Handle writes the record to the underlying Logger.
+func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := make([]Field, len(h.fields), len(h.fields)+r.NumAttrs())
+	copy(fields, h.fields)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.prefix, a)
+		return true
+	})
+	rec := Record{
+		Level:   LevelFromSlog(r.Level),
+		Time:    r.Time,
+		Message: r.Message,
+		Fields:  fields,
+	}
+	if r.PC != 0 {
+		rec.Caller, _ = runtime.CallersFrames([]uintptr{r.PC}).Next()
+	}
+	_, err := h.l.Output(rec)
+	return err
+}
+
+// WithAttrs returns a new SlogHandler whose records will carry attrs in addition to the handler's attributes.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]Field, len(h.fields), len(h.fields)+len(attrs))
+	copy(fields, h.fields)
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, h.prefix, a)
+	}
+	return &SlogHandler{l: h.l, fields: fields, prefix: h.prefix}
+}
+
+// WithGroup returns a new SlogHandler that puts all following attributes into the group name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if len(name) < 1 {
+		return h
+	}
+	return &SlogHandler{l: h.l, fields: h.fields, prefix: h.prefix + name + "."}
+}
+
+// appendSlogAttr appends a as field to fields. Groups are flattened, empty attributes are skipped.
+func appendSlogAttr(fields []Field, prefix string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if len(a.Key) > 0 {
+			prefix = prefix + a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendSlogAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+	return append(fields, Field{Key: prefix + a.Key, Value: a.Value.Any()})
+}
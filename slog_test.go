@@ -0,0 +1,39 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogLevelMapping(t *testing.T) {
+	for lvl := LevelPanic; lvl <= LevelTrace; lvl++ {
+		if got := LevelFromSlog(SlogLevel(lvl)); got != lvl {
+			t.Errorf("Level %s was mapped back to level %s", lvl, got)
+		}
+	}
+	if got := LevelFromSlog(slog.LevelWarn + 1); got != LevelWarning {
+		t.Errorf("Expected level %s, got level %s", LevelWarning, got)
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	sl := slog.New(NewSlogHandler(l))
+	sl.Debug("invisible")
+	if b.String() != "" {
+		t.Errorf("Record %q should not have been printed", b.String())
+	}
+	sl.With("id", 7).WithGroup("req").Warn("slow request", "path", "/", slog.Group("timing", "ms", 1200), slog.Attr{})
+	expect := fmt.Sprintf("[%s]%sslow request%sid=7 req.path=/ req.timing.ms=1200\n",
+		LevelWarning.String(),
+		loglevelDelimiter,
+		loglevelDelimiter)
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
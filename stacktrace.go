@@ -0,0 +1,78 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxStackDepth is the maximum number of stack frames of a stack trace attached to a record.
+const maxStackDepth = 64
+
+// SetStacktraceLevel attaches a stack trace of the logging goroutine to all records whose loglevel is equally
+// severe or more severe than level, e.g. LevelCritical. The trace starts at the code that logged the record.
+// Passing LevelInvalid disables stack traces, which is the default. Passing another invalid loglevel will cause a panic.
+func (l *Logger) SetStacktraceLevel(level Level) {
+	if level != LevelInvalid {
+		assertLoglevel(level)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stacktraceLevel = level
+	l.snapshotShards()
+}
+
+// StacktraceLevel returns the least severe loglevel whose records get a stack trace attached.
+// It returns LevelInvalid if stack traces are disabled.
+func (l *Logger) StacktraceLevel() Level {
+	defer l.runlock(l.rlock())
+	return l.stacktraceLevel
+}
+
+// needsStack returns true if a stack trace has to be captured for rec. The caller must hold the Logger's lock
+// or its read lock.
+func (l *Logger) needsStack(rec *Record) bool {
+	if len(rec.Stack) > 0 {
+		return false
+	}
+	return rec.trace || l.stacktraceLevel != LevelInvalid && rec.Level <= l.stacktraceLevel
+}
+
+// stacktrace returns the stack trace of the calling goroutine, starting at the first frame outside of the logging
+// machinery and skipping the configured number of additional frames. Each frame is rendered as the function's name
+// followed by a line with a tab, the file and the line number. The caller must hold the Logger's lock.
+func (l *Logger) stacktrace() string {
+	var pcs [maxStackDepth]uintptr
+	return stacktraceOf(pcs[:runtime.Callers(2, pcs[:])], l.callerSkip)
+}
+
+// stacktraceOf returns the stack trace described by the program counters pcs like stacktrace does,
+// skipping skip additional frames.
+func stacktraceOf(pcs []uintptr, skip int) string {
+	frames := runtime.CallersFrames(pcs)
+	b := new(strings.Builder)
+	frame, more := frames.Next()
+	for more && skipFrame(frame.Function) {
+		frame, more = frames.Next()
+	}
+	for ; len(frame.Function) > 0 || len(frame.File) > 0; frame, more = frames.Next() {
+		if skip > 0 {
+			skip--
+		} else {
+			if b.Len() > 0 {
+				b.WriteByte('\n')
+			}
+			b.WriteString(frame.Function)
+			b.WriteString("\n\t")
+			b.WriteString(frame.File)
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
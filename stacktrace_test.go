@@ -0,0 +1,37 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStacktraceLevel(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	if l.StacktraceLevel() != LevelInvalid {
+		t.Errorf("Expected stack traces to be disabled by default")
+	}
+	l.SetStacktraceLevel(LevelError)
+	l.Warning("no trace")
+	if b.String() != "[Warning] - no trace\n" {
+		t.Errorf("Expected %q. Got %q", "[Warning] - no trace\n", b.String())
+	}
+	b.Reset()
+	l.Critical("trace")
+	lines := strings.Split(b.String(), "\n")
+	if lines[0] != "[Critical] - trace" {
+		t.Errorf("Expected %q. Got %q", "[Critical] - trace", lines[0])
+	}
+	if len(lines) < 3 || lines[1] != "github.com/jwdev42/logger.TestStacktraceLevel" ||
+		!strings.HasPrefix(lines[2], "\t") || !strings.Contains(lines[2], "stacktrace_test.go:") {
+		t.Errorf("Expected a stack trace starting at the test function. Got %q", b.String())
+	}
+	l.SetStacktraceLevel(LevelInvalid)
+	b.Reset()
+	l.Critical("trace")
+	if b.String() != "[Critical] - trace\n" {
+		t.Errorf("Expected %q. Got %q", "[Critical] - trace\n", b.String())
+	}
+}
@@ -0,0 +1,28 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// Stats holds counters about the records a Logger processed since its creation.
+type Stats struct {
+	Records     map[Level]uint64 // Number of records passed to the outputs per loglevel.
+	Bytes       uint64           // Number of bytes written to all outputs.
+	Dropped     uint64           // Number of records discarded, see Dropped.
+	WriteErrors uint64           // Number of writes that failed and could not be recovered by the error policy.
+}
+
+// Stats returns the statistics of the Logger. The counters are shared by all Loggers derived from the same Logger.
+// Stats does not need the Logger's lock, so it can be called from within the Logger, e.g. by a Hook.
+func (l *Logger) Stats() Stats {
+	l.statsMu.Lock()
+	records := make(map[Level]uint64, len(l.records))
+	for level, count := range l.records {
+		records[level] = count
+	}
+	l.statsMu.Unlock()
+	return Stats{
+		Records:     records,
+		Bytes:       l.bytesWritten.Load(),
+		Dropped:     l.dropped.Load(),
+		WriteErrors: l.writeErrors.Load(),
+	}
+}
@@ -0,0 +1,33 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	out := new(strings.Builder)
+	l := New(out, LevelInfo, loglevelDelimiter)
+	l.AddOutput(failingWriter{}, LevelError)
+	l.Info("one")
+	l.WithPrefix("child").Info("two")
+	l.Error("three")
+	l.Debug("filtered")
+	l.Close()
+	l.Info("closed")
+	stats := l.Stats()
+	if stats.Records[LevelInfo] != 2 || stats.Records[LevelError] != 1 || stats.Records[LevelDebug] != 0 {
+		t.Errorf("Unexpected record counts %v", stats.Records)
+	}
+	if stats.Bytes != uint64(out.Len()) {
+		t.Errorf("Expected %d bytes. Got %d", out.Len(), stats.Bytes)
+	}
+	if stats.Dropped != 1 {
+		t.Errorf("Expected 1 dropped record. Got %d", stats.Dropped)
+	}
+	if stats.WriteErrors != 1 {
+		t.Errorf("Expected 1 write error. Got %d", stats.WriteErrors)
+	}
+}
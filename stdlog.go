@@ -0,0 +1,28 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import "log"
+
+// stdWriter is an io.Writer that sends everything written to it to a Logger as a record of a fixed level.
+type stdWriter struct {
+	l     *Logger
+	level Level
+}
+
+// StdLogger returns a *log.Logger of the standard library that sends its output to l as records of the given level.
+// It can be passed to libraries that only accept a *log.Logger, e.g. as http.Server.ErrorLog. The returned *log.Logger
+// has no prefix and no flags, timestamps and caller information are added by l. Passing an invalid loglevel will cause a panic.
+func (l *Logger) StdLogger(level Level) *log.Logger {
+	assertLoglevel(level)
+	return log.New(&stdWriter{l: l, level: level}, "", 0)
+}
+
+// Write sends p as a record to the Logger. It always reports p as completely written, so the *log.Logger
+// does not treat a filtered record as error.
+func (w *stdWriter) Write(p []byte) (n int, err error) {
+	if _, err := w.l.Output(Record{Level: w.level, Message: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,23 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStdLogger(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelWarning, loglevelDelimiter)
+	l.SetReportCaller(true)
+	l.StdLogger(LevelInfo).Print("filtered")
+	std := l.StdLogger(LevelError)
+	line := lineOfCaller() + 1
+	std.Printf("http: TLS handshake error from %s", "10.0.0.1")
+	expect := fmt.Sprintf("[Error] - stdlog_test.go:%d - http: TLS handshake error from 10.0.0.1\n", line)
+	if b.String() != expect {
+		t.Errorf("Expected %q. Got %q", expect, b.String())
+	}
+}
@@ -0,0 +1,166 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// templateSegments maps the placeholders of a template to the segments they are replaced by.
+var templateSegments = map[string]Segment{
+	"level":    SegmentLevel,
+	"time":     SegmentTime,
+	"caller":   SegmentCaller,
+	"msg":      SegmentMessage,
+	"fields":   SegmentFields,
+	"hostname": SegmentHostname,
+}
+
+// templatePart is a literal text or a placeholder of a parsed template.
+type templatePart struct {
+	literal string
+	seg     Segment
+	width   int // Minimum width of the placeholder, a negative width aligns it to the left.
+	isSeg   bool
+}
+
+// template is a parsed record template.
+type template struct {
+	source string
+	parts  []templatePart
+}
+
+// SetTemplate renders the Logger's records in FormatText by the given template instead of the layout and the
+// delimiter. Placeholders in braces are replaced by the parts of a record: {level}, {time}, {caller}, {msg},
+// {fields} and {hostname}. A placeholder may carry a minimum width like fmt's verbs do, {level:9} pads the level
+// tag with spaces on the left to 9 characters, {level:-9} pads it on the right. Placeholders without content are
+// replaced by spaces up to their width. "{{" and "}}" stand for literal braces. Example:
+//
+//	l.SetTemplate("{time} {level:-9} {caller} | {msg} {fields}")
+//
+// An empty template restores the layout. If template is malformed, an error is returned and the Logger is not changed.
+func (l *Logger) SetTemplate(tmpl string) error {
+	var t *template
+	if len(tmpl) > 0 {
+		var err error
+		if t, err = parseTemplate(tmpl); err != nil {
+			return err
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.template = t
+	return nil
+}
+
+// Template returns the Logger's record template, an empty string if it uses the layout.
+func (l *Logger) Template() string {
+	defer l.runlock(l.rlock())
+	if l.template == nil {
+		return ""
+	}
+	return l.template.source
+}
+
+// parseTemplate parses a record template as described at SetTemplate.
+func parseTemplate(tmpl string) (*template, error) {
+	t := &template{source: tmpl}
+	literal := new(strings.Builder)
+	for i := 0; i < len(tmpl); i++ {
+		switch c := tmpl[i]; {
+		case c == '{' && strings.HasPrefix(tmpl[i:], "{{"), c == '}' && strings.HasPrefix(tmpl[i:], "}}"):
+			literal.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(tmpl[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("Template %q: Unclosed placeholder at position %d", tmpl, i)
+			}
+			part, err := parsePlaceholder(tmpl[i+1 : i+end])
+			if err != nil {
+				return nil, fmt.Errorf("Template %q: %w", tmpl, err)
+			}
+			if literal.Len() > 0 {
+				t.parts = append(t.parts, templatePart{literal: literal.String()})
+				literal.Reset()
+			}
+			t.parts = append(t.parts, part)
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("Template %q: Unexpected '}' at position %d", tmpl, i)
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	if literal.Len() > 0 {
+		t.parts = append(t.parts, templatePart{literal: literal.String()})
+	}
+	return t, nil
+}
+
+// parsePlaceholder parses the content of a placeholder, a name and an optional width separated by a colon.
+func parsePlaceholder(s string) (templatePart, error) {
+	name, width, hasWidth := strings.Cut(s, ":")
+	seg, ok := templateSegments[name]
+	if !ok {
+		return templatePart{}, fmt.Errorf("Unknown placeholder %q", name)
+	}
+	part := templatePart{seg: seg, isSeg: true}
+	if hasWidth {
+		var err error
+		if part.width, err = strconv.Atoi(width); err != nil {
+			return templatePart{}, fmt.Errorf("Invalid width %q of placeholder %q", width, name)
+		}
+	}
+	return part, nil
+}
+
+// encodeTemplate appends rec rendered by the Logger's template to b. A stack trace follows the record on
+// separate lines. The caller must hold the Logger's lock.
+func (l *Logger) encodeTemplate(b []byte, rec *Record) []byte {
+	for _, part := range l.template.parts {
+		if !part.isSeg {
+			b = append(b, part.literal...)
+			continue
+		}
+		start := len(b)
+		var ok bool
+		if b, ok = l.appendSegment(b, part.seg, rec); !ok {
+			b = b[:start]
+		}
+		b = padSegment(b, start, part.width)
+	}
+	if len(rec.Stack) > 0 {
+		b = append(b, '\n')
+		b = append(b, rec.Stack...)
+	}
+	return append(b, '\n')
+}
+
+// padSegment pads the segment b[start:] with spaces to the width of a template placeholder.
+// ANSI color sequences do not count towards the width.
+func padSegment(b []byte, start, width int) []byte {
+	left := width < 0
+	if left {
+		width = -width
+	}
+	padding := width - utf8.RuneCountInString(stripANSI(string(b[start:])))
+	if padding <= 0 {
+		return b
+	}
+	if left {
+		for ; padding > 0; padding-- {
+			b = append(b, ' ')
+		}
+		return b
+	}
+	seg := append([]byte(nil), b[start:]...)
+	b = b[:start]
+	for ; padding > 0; padding-- {
+		b = append(b, ' ')
+	}
+	return append(b, seg...)
+}
@@ -0,0 +1,58 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplate(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetTimeFormat("15:04:05")
+	l.SetClock(func() time.Time { return time.Date(2023, 5, 1, 12, 0, 0, 0, time.Local) })
+	if err := l.SetTemplate("{time} {level:-9} {caller:4}| {msg} {{{fields}}}"); err != nil {
+		t.Fatal(err)
+	}
+	l.InfoKV("started", "port", 80)
+	l.SetColor(ColorAlways)
+	l.Warning("slow")
+	expected := "12:00:00 [Info]        | started {port=80}\n" +
+		"12:00:00 " + ansiYellow + "[Warning]" + ansiReset + "     | slow {}\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+	if l.Template() == "" {
+		t.Error("Expected a template")
+	}
+	if err := l.SetTemplate(""); err != nil || l.Template() != "" {
+		t.Errorf("Expected the template to be removed. Got %q, %v", l.Template(), err)
+	}
+}
+
+func TestTemplateInvalid(t *testing.T) {
+	l := New(new(strings.Builder), LevelInfo, loglevelDelimiter)
+	for _, tmpl := range []string{"{msg", "{message}", "{level:wide}", "msg}"} {
+		if err := l.SetTemplate(tmpl); err == nil {
+			t.Errorf("Expected an error for template %q", tmpl)
+		}
+	}
+}
+
+func TestPadSegment(t *testing.T) {
+	tests := []struct {
+		width    int
+		expected string
+	}{
+		{5, "   äb"},
+		{-5, "äb   "},
+		{1, "äb"},
+	}
+	for _, test := range tests {
+		if got := string(padSegment([]byte("äb"), 0, test.width)); got != test.expected {
+			t.Errorf("Expected %q. Got %q", test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"time"
+)
+
+// ErrWriteTimeout is passed to the error handler if a write to an output did not complete within the
+// Logger's write timeout.
+var ErrWriteTimeout = errors.New("Write timed out")
+
+// stalledWrite is a write that exceeded the write timeout and is still blocking.
+type stalledWrite struct {
+	key  any // See stallKey.
+	done chan struct{}
+}
+
+// destination identifies one of the writers of a Logger.
+type destination struct {
+	output int   // Index of an output added by AddOutput, -1 for the Logger's writer.
+	level  Level // Loglevel the writer was set for by SetLevelOutput, LevelInvalid for the Logger's writer.
+}
+
+// mainDestination is the Logger's writer.
+var mainDestination = destination{output: -1, level: LevelInvalid}
+
+// stallKey returns the key of the writes to w at dest for tracking stalled writes. A comparable writer is its own
+// key, so a writer used by several destinations is stalled for all of them. Writers that are not comparable
+// cannot be told apart, they are keyed by dest.
+func stallKey(dest destination, w io.Writer) any {
+	if reflect.TypeOf(w).Comparable() {
+		return w
+	}
+	return dest
+}
+
+// SetWriteTimeout bounds the time a write to one of the Logger's outputs may block, d <= 0 disables the
+// timeout (default). If a write times out, the record is dropped for that output, ErrWriteTimeout is
+// passed to the error handler and the drop counter is incremented, see Dropped. Further records for that
+// output are dropped immediately until the blocking write returns.
+func (l *Logger) SetWriteTimeout(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if d < 0 {
+		d = 0
+	}
+	l.writeTimeout = d
+}
+
+// WriteTimeout returns the Logger's write timeout, 0 means writes may block indefinitely.
+func (l *Logger) WriteTimeout() time.Duration {
+	defer l.runlock(l.rlock())
+	return l.writeTimeout
+}
+
+// writeWithTimeout writes b to w at dest in a separate goroutine and waits at most for the Logger's write timeout.
+// The caller must hold the Logger's lock.
+func (l *Logger) writeWithTimeout(dest destination, w io.Writer, b []byte) (int, error) {
+	key := stallKey(dest, w)
+	if l.isStalled(key) {
+		return 0, ErrWriteTimeout
+	}
+	type result struct {
+		n   int
+		err error
+	}
+	p := append([]byte(nil), b...) // b is pooled and must not be used after the timeout.
+	c := make(chan result, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		n, err := w.Write(p)
+		c <- result{n, err}
+	}()
+	timer := time.NewTimer(l.writeTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-c:
+		return r.n, r.err
+	case <-timer.C:
+		l.stalled = append(l.stalled, stalledWrite{key: key, done: done})
+		return 0, ErrWriteTimeout
+	}
+}
+
+// isStalled returns true if a timed out write with the stall key key is still blocking, see stallKey. Writes that
+// returned in the meantime are forgotten. The caller must hold the Logger's lock.
+func (l *Logger) isStalled(key any) bool {
+	stalled := false
+	pending := l.stalled[:0]
+	for _, s := range l.stalled {
+		select {
+		case <-s.done:
+			continue
+		default:
+		}
+		if s.key == key {
+			stalled = true
+		}
+		pending = append(pending, s)
+	}
+	l.stalled = pending
+	return stalled
+}
@@ -0,0 +1,102 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingWriter blocks every write until release is closed.
+type blockingWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	b       strings.Builder
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.b.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.b.String()
+}
+
+func TestWriteTimeout(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	var errs []error
+	l := New(w, LevelInfo, loglevelDelimiter)
+	l.SetErrorHandler(func(err error) { errs = append(errs, err) })
+	l.SetWriteTimeout(10 * time.Millisecond)
+	start := time.Now()
+	if _, err := l.Info("first"); err != ErrWriteTimeout {
+		t.Errorf("Expected %v. Got %v", ErrWriteTimeout, err)
+	}
+	if _, err := l.Info("second"); err != ErrWriteTimeout {
+		t.Errorf("Expected %v. Got %v", ErrWriteTimeout, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Writes blocked for %s", elapsed)
+	}
+	if l.Dropped() != 2 || len(errs) != 2 {
+		t.Errorf("Expected 2 dropped records and errors. Got %d and %d", l.Dropped(), len(errs))
+	}
+	close(w.release)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		l.mu.Lock()
+		stalled := l.isStalled(w)
+		l.mu.Unlock()
+		if !stalled {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := l.Info("third"); err != nil {
+		t.Error(err)
+	}
+	expected := "[Info] - first\n[Info] - third\n"
+	if got := w.String(); got != expected {
+		t.Errorf("Expected %q. Got %q", expected, got)
+	}
+}
+
+// blockingSink blocks every write until release is closed. It is not comparable.
+type blockingSink struct {
+	release chan struct{}
+	writes  *atomic.Int32
+	_       []byte
+}
+
+func (w blockingSink) Write(p []byte) (int, error) {
+	w.writes.Add(1)
+	<-w.release
+	return len(p), nil
+}
+
+func TestWriteTimeoutNotComparable(t *testing.T) {
+	w := blockingSink{release: make(chan struct{}), writes: new(atomic.Int32)}
+	defer close(w.release)
+	l := New(io.Discard, LevelInfo, loglevelDelimiter)
+	l.AddOutput(w, LevelInfo)
+	l.SetWriteTimeout(10 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		l.Info("record")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := w.writes.Load(); n > 1 {
+		t.Errorf("Expected a single blocking write. Got %d", n)
+	}
+	if l.Dropped() != 5 {
+		t.Errorf("Expected 5 dropped records. Got %d", l.Dropped())
+	}
+}
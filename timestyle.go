@@ -0,0 +1,136 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TimeLocal     TimeStyle = iota //Timestamps are formatted in local time according to the Logger's time format.
+	TimeUTC                        //Timestamps are formatted in UTC according to the Logger's time format.
+	TimeElapsed                    //Timestamps show the seconds elapsed since the Logger was created, e.g. "12.000345".
+	TimeUnixMilli                  //Timestamps show the milliseconds since the Unix epoch.
+	TimeUnixNano                   //Timestamps show the nanoseconds since the Unix epoch.
+)
+
+// Represents the way a Logger renders the timestamps of its records.
+type TimeStyle int
+
+// Panics if the time style does not exist.
+func assertTimeStyle(style TimeStyle) {
+	if err := checkTimeStyle(style); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Returns an error if the time style does not exist.
+func checkTimeStyle(style TimeStyle) error {
+	if style < TimeLocal || style > TimeUnixNano {
+		return fmt.Errorf("Time style %d is not defined", style)
+	}
+	return nil
+}
+
+// String returns the string representation of a TimeStyle. If the TimeStyle is
+// not defined, String returns "Undefined".
+func (s TimeStyle) String() string {
+	switch s {
+	case TimeLocal:
+		return "Local"
+	case TimeUTC:
+		return "UTC"
+	case TimeElapsed:
+		return "Elapsed"
+	case TimeUnixMilli:
+		return "UnixMilli"
+	case TimeUnixNano:
+		return "UnixNano"
+	}
+	return "Undefined"
+}
+
+// ParseTimeStyle returns the TimeStyle whose string representation matches input, ignoring case.
+// On failure, it returns TimeLocal and an error.
+func ParseTimeStyle(input string) (TimeStyle, error) {
+	switch strings.ToLower(input) {
+	case "local":
+		return TimeLocal, nil
+	case "utc":
+		return TimeUTC, nil
+	case "elapsed":
+		return TimeElapsed, nil
+	case "unixmilli":
+		return TimeUnixMilli, nil
+	case "unixnano":
+		return TimeUnixNano, nil
+	}
+	return TimeLocal, fmt.Errorf("Input sequence %q cannot be associated with a defined time style", input)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the string representation of the TimeStyle,
+// or an error if the TimeStyle is not defined.
+func (s TimeStyle) MarshalText() ([]byte, error) {
+	if err := checkTimeStyle(s); err != nil {
+		return nil, err
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts all input ParseTimeStyle accepts.
+func (s *TimeStyle) UnmarshalText(text []byte) error {
+	style, err := ParseTimeStyle(string(text))
+	if err != nil {
+		return err
+	}
+	*s = style
+	return nil
+}
+
+// SetTimeStyle sets how the Logger renders timestamps. TimeLocal and TimeUTC use the time format
+// set by SetTimeFormat, a Logger without time format renders no timestamps in FormatText.
+// The other styles ignore the time format and always render a timestamp. The default is TimeLocal.
+// Setting an invalid style will cause a panic.
+func (l *Logger) SetTimeStyle(style TimeStyle) {
+	assertTimeStyle(style)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timeStyle = style
+}
+
+// TimeStyle returns how the Logger renders timestamps.
+func (l *Logger) TimeStyle() TimeStyle {
+	defer l.runlock(l.rlock())
+	return l.timeStyle
+}
+
+// appendTime appends t rendered according to the Logger's time style to b. If the style uses the time format and
+// the Logger has none, defaultFormat is used. ok is false if no timestamp was rendered.
+// The caller must hold the Logger's lock.
+func (l *Logger) appendTime(b []byte, t time.Time, defaultFormat string) (_ []byte, ok bool) {
+	created := l.created
+	if l.deterministic.Load() {
+		created, t = t, t.UTC()
+	}
+	switch l.timeStyle {
+	case TimeElapsed:
+		return strconv.AppendFloat(b, t.Sub(created).Seconds(), 'f', 6, 64), true
+	case TimeUnixMilli:
+		return strconv.AppendInt(b, t.UnixMilli(), 10), true
+	case TimeUnixNano:
+		return strconv.AppendInt(b, t.UnixNano(), 10), true
+	case TimeUTC:
+		t = t.UTC()
+	}
+	format := l.timeFormat
+	if len(format) < 1 {
+		format = defaultFormat
+	}
+	if len(format) < 1 {
+		return b, false
+	}
+	return t.AppendFormat(b, format), true
+}
@@ -0,0 +1,63 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeStyle(t *testing.T) {
+	ts := time.Date(2023, 5, 1, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	tests := []struct {
+		style  TimeStyle
+		format string
+		want   string
+	}{
+		{TimeLocal, "", "[Info] - msg\n"},
+		{TimeLocal, time.Kitchen, "[Info] - 12:30PM - msg\n"},
+		{TimeUTC, time.Kitchen, "[Info] - 10:30AM - msg\n"},
+		{TimeUnixMilli, "", "[Info] - " + strconv.FormatInt(ts.UnixMilli(), 10) + " - msg\n"},
+		{TimeUnixNano, time.Kitchen, "[Info] - " + strconv.FormatInt(ts.UnixNano(), 10) + " - msg\n"},
+	}
+	for _, test := range tests {
+		b := new(strings.Builder)
+		l := New(b, LevelInfo, loglevelDelimiter)
+		l.SetTimeFormat(test.format)
+		l.SetTimeStyle(test.style)
+		if l.TimeStyle() != test.style {
+			t.Errorf("Expected time style %s. Got %s", test.style, l.TimeStyle())
+		}
+		l.Output(Record{Level: LevelInfo, Time: ts, Message: "msg"})
+		if b.String() != test.want {
+			t.Errorf("%s: Expected %q. Got %q", test.style, test.want, b.String())
+		}
+	}
+}
+
+func TestTimeStyleElapsed(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.SetTimeStyle(TimeElapsed)
+	l.SetFormat(FormatJSON)
+	l.Output(Record{Level: LevelInfo, Time: l.created.Add(1500 * time.Millisecond), Message: "msg"})
+	rec := new(jsonRecord)
+	if err := json.Unmarshal([]byte(b.String()), rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Time != "1.500000" {
+		t.Errorf("Expected %q. Got %q", "1.500000", rec.Time)
+	}
+}
+
+func TestTimeStyleInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an invalid time style")
+		}
+	}()
+	New(new(strings.Builder), LevelInfo, loglevelDelimiter).SetTimeStyle(TimeUnixNano + 1)
+}
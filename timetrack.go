@@ -0,0 +1,19 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+// TimeTrack measures the duration of an operation and returns the function that ends the measurement. It sends
+// a record with name as message and the field "elapsed" holding the duration, e.g. "rebuild index - elapsed=1.5s".
+// The duration is measured by the Logger's clock, see SetClock, and is 0 in deterministic mode.
+// Whether the record is written depends on the loglevel when the operation ends:
+//
+//	defer l.TimeTrack(logger.LevelDebug, "rebuild index")()
+//
+// Setting an invalid loglevel will cause a panic.
+func (l *Logger) TimeTrack(level Level, name string) (stop func()) {
+	assertLoglevel(level)
+	start := l.now()
+	return func() {
+		l.PrintKV(level, name, "elapsed", l.now().Sub(start))
+	}
+}
@@ -0,0 +1,29 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeTrack(t *testing.T) {
+	ts := time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)
+	b := new(strings.Builder)
+	l := New(b, LevelDebug, loglevelDelimiter)
+	l.SetClock(func() time.Time {
+		ts = ts.Add(1500 * time.Millisecond)
+		return ts
+	})
+	l.SetTimeFormat("")
+	func() {
+		defer l.TimeTrack(LevelDebug, "rebuild index")()
+	}()
+	stop := l.TimeTrack(LevelDebug, "filtered")
+	l.SetLevel(LevelInfo)
+	stop()
+	if b.String() != "[Debug] - rebuild index - elapsed=1.5s\n" {
+		t.Errorf("Expected %q. Got %q", "[Debug] - rebuild index - elapsed=1.5s\n", b.String())
+	}
+}
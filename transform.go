@@ -0,0 +1,52 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import "strings"
+
+// Transformer rewrites the message of a record, e.g. to emphasize severe records.
+type Transformer func(msg string) string
+
+// AddTransformer adds a Transformer that is applied to the messages of all records of the given levels before
+// they are redacted and passed to hooks, sinks and outputs. Transformers are applied in the order they were added.
+// Passing nil as t, no levels or an invalid loglevel will cause a panic.
+func (l *Logger) AddTransformer(t Transformer, levels ...Level) {
+	if t == nil {
+		panic("Programming error: (l *Logger) AddTransformer(): Passed nil as transformer")
+	}
+	if len(levels) < 1 {
+		panic("Programming error: (l *Logger) AddTransformer(): Passed no loglevels")
+	}
+	for _, lvl := range levels {
+		assertLoglevel(lvl)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.transformers == nil {
+		l.transformers = make(map[Level][]Transformer)
+	}
+	for _, lvl := range levels {
+		l.transformers[lvl] = append(l.transformers[lvl], t)
+	}
+}
+
+// PrefixTransformer returns a Transformer that puts prefix in front of the message,
+// e.g. PrefixTransformer("ACTION REQUIRED: ").
+func PrefixTransformer(prefix string) Transformer {
+	return func(msg string) string {
+		return prefix + msg
+	}
+}
+
+// UpperTransformer is a Transformer that converts the message to upper case.
+func UpperTransformer(msg string) string {
+	return strings.ToUpper(msg)
+}
+
+// transform applies the transformers registered for the level of rec to its message.
+// The caller must hold the Logger's lock.
+func (l *Logger) transform(rec *Record) {
+	for _, t := range l.transformers[rec.Level] {
+		rec.Message = t(rec.Message)
+	}
+}
@@ -0,0 +1,25 @@
+//This file is part of logger. ©2020-2023 Jörg Walter.
+
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddTransformer(t *testing.T) {
+	b := new(strings.Builder)
+	l := New(b, LevelInfo, loglevelDelimiter)
+	l.AddTransformer(UpperTransformer, LevelPanic)
+	l.AddTransformer(PrefixTransformer("ACTION REQUIRED: "), LevelAlert, LevelPanic)
+	l.AddRedactPattern(`secret`)
+	l.Panic("disk full")
+	l.Alert("rotate the secret")
+	l.Info("unchanged")
+	expected := "[Panic] - ACTION REQUIRED: DISK FULL\n" +
+		"[Alert] - ACTION REQUIRED: rotate the [REDACTED]\n" +
+		"[Info] - unchanged\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, b.String())
+	}
+}